@@ -18,6 +18,7 @@ package fr
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
 	"errors"
 	"io"
@@ -27,11 +28,15 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
 // Element represents a field element stored on 4 words (uint64)
 //
 // Element are assumed to be in Montgomery form in all methods.
+// Conversions from and to big.Int follow this convention: SetBigInt and ToBigIntRegular
+// deal with regular integers, while ToBigInt returns the Montgomery form.
 //
 // Modulus q =
 //
@@ -107,6 +112,8 @@ func (z *Element) SetUint64(v uint64) *Element {
 }
 
 // SetInt64 sets z to v and returns z
+//
+// Negative values are mapped to q - |v|; for instance SetInt64(-1) sets z to q - 1.
 func (z *Element) SetInt64(v int64) *Element {
 
 	// absolute value of v
@@ -209,6 +216,9 @@ func (z *Element) SetOne() *Element {
 }
 
 // Div z = x*y⁻¹ (mod q)
+//
+// It performs a single inversion. There is no zero-divisor check: if y == 0, sets and returns z = 0
+// (see Inverse), callers that must reject a zero divisor check y.IsZero() first.
 func (z *Element) Div(x, y *Element) *Element {
 	var yInv Element
 	yInv.Inverse(y)
@@ -306,19 +316,25 @@ func (z *Element) Cmp(x *Element) int {
 // larger than its negation, false otherwise
 func (z *Element) LexicographicallyLargest() bool {
 	// adapted from github.com/zkcrypto/bls12_381
-	// we check if the element is larger than (q-1) / 2
-	// if z - (((q -1) / 2) + 1) have no underflow, then z > (q-1) / 2
+	// we check if the element is larger than (q-1) / 2, that is, if z >= ((q-1) / 2) + 1;
+	// limbs are compared from the most significant one, the first that differs decides.
 
 	_z := *z
 	_z.FromMont()
+	if _z[3] != 672640185344086699 {
+		return _z[3] > 672640185344086699
+	}
+	if _z[2] != 3484139658120255488 {
+		return _z[2] > 3484139658120255488
+	}
+	if _z[1] != 12453925762954690560 {
+		return _z[1] > 12453925762954690560
+	}
+	if _z[0] != 9586122913090633729 {
+		return _z[0] > 9586122913090633729
+	}
 
-	var b uint64
-	_, b = bits.Sub64(_z[0], 9586122913090633729, 0)
-	_, b = bits.Sub64(_z[1], 12453925762954690560, b)
-	_, b = bits.Sub64(_z[2], 3484139658120255488, b)
-	_, b = bits.Sub64(_z[3], 672640185344086699, b)
-
-	return b == 0
+	return true
 }
 
 // SetRandom sets z to a uniform random value in [0, q).
@@ -374,6 +390,30 @@ func (z *Element) smallerThanModulus() bool {
 	return (z[3] < q3 || (z[3] == q3 && (z[2] < q2 || (z[2] == q2 && (z[1] < q1 || (z[1] == q1 && (z[0] < q0)))))))
 }
 
+// IsReduced returns true if the limbs of z represent a value strictly smaller than q.
+//
+// Elements produced by the arithmetic of this package are always reduced; a non-reduced
+// element can only be built by setting the limbs directly, and is meant to be detected
+// when debugging or validating untrusted data. This is not constant time.
+func (z *Element) IsReduced() bool {
+	return z.smallerThanModulus()
+}
+
+// Canonical returns a copy of z, in Montgomery form, with fully reduced limbs (i.e. smaller than q).
+//
+// Elements equal as per Equal have identical Canonical limbs; it is meant to be used
+// when the raw limbs are hashed or compared directly.
+func (z *Element) Canonical() Element {
+	res := *z
+	if !res.smallerThanModulus() {
+		// the limbs may encode a value ⩾ 2q, so subtracting q once is not enough;
+		// SetBigInt reduces modulo q, and FromMont undoes its conversion to Montgomery form
+		var v big.Int
+		res.SetBigInt(z.ToBigInt(&v)).FromMont()
+	}
+	return res
+}
+
 // One returns 1
 func One() Element {
 	var one Element
@@ -459,6 +499,25 @@ func (z *Element) Square(x *Element) *Element {
 	return z
 }
 
+// SquareAssign z = z * z (mod q)
+//
+// z must be strictly inferior to q
+func (z *Element) SquareAssign() *Element {
+	return z.Square(z)
+}
+
+// SquareN z = x^(2ⁿ) (mod q), that is x squared n times.
+// It returns x if n <= 0.
+//
+// x must be strictly inferior to q
+func (z *Element) SquareN(x *Element, n int) *Element {
+	z.Set(x)
+	for i := 0; i < n; i++ {
+		z.Square(z)
+	}
+	return z
+}
+
 // FromMont converts z in place (i.e. mutates) from Montgomery to regular representation
 // sets and returns z = z * 1
 func (z *Element) FromMont() *Element {
@@ -506,6 +565,24 @@ func (z *Element) Double(x *Element) *Element {
 	return z
 }
 
+// MulByConstant x *= c (mod q) for a small constant c
+//
+// It runs a double-and-add chain over the bits of c, which is cheaper than a Mul;
+// MulBy3, MulBy5 and MulBy13 cover the common constants.
+func MulByConstant(x *Element, c uint8) {
+	if c == 0 {
+		x.SetZero()
+		return
+	}
+	_x := *x
+	for i := bits.Len8(c) - 2; i >= 0; i-- {
+		x.Double(x)
+		if (c>>uint(i))&1 == 1 {
+			x.Add(x, &_x)
+		}
+	}
+}
+
 // Sub z = x - y (mod q)
 func (z *Element) Sub(x, y *Element) *Element {
 	var b uint64
@@ -713,6 +790,21 @@ func BatchInvert(a []Element) []Element {
 	return res
 }
 
+// VecScale sets dst[i] = a[i] ⋅ sⁱ for i in [0, len(a)), computing the powers of s on the fly,
+// e.g. to move the coefficients of a polynomial P to those of P(s⋅X) before a coset FFT.
+//
+// dst and a may be the same slice. It panics if len(dst) < len(a).
+func VecScale(dst, a []Element, s Element) {
+	if len(dst) < len(a) {
+		panic("VecScale: len(dst) < len(a)")
+	}
+	acc := One()
+	for i := 0; i < len(a); i++ {
+		dst[i].Mul(&a[i], &acc)
+		acc.Mul(&acc, &s)
+	}
+}
+
 func _butterflyGeneric(a, b *Element) {
 	t := *a
 	a.Add(a, b)
@@ -827,6 +919,9 @@ func (z *Element) Text(base int) string {
 }
 
 // ToBigInt returns z as a big.Int in Montgomery form
+//
+// Note that the result is z⋅R mod q, not the integer z represents;
+// use ToBigIntRegular to obtain the latter (which is the inverse of SetBigInt).
 func (z *Element) ToBigInt(res *big.Int) *big.Int {
 	var b [Limbs * 8]byte
 	binary.BigEndian.PutUint64(b[24:32], z[0])
@@ -838,6 +933,8 @@ func (z *Element) ToBigInt(res *big.Int) *big.Int {
 }
 
 // ToBigIntRegular returns z as a big.Int in regular form
+//
+// This is the inverse of SetBigInt: for any 0 ⩽ v < q, z.SetBigInt(v).ToBigIntRegular(res) sets res to v.
 func (z Element) ToBigIntRegular(res *big.Int) *big.Int {
 	z.FromMont()
 	return z.ToBigInt(res)
@@ -876,7 +973,127 @@ func (z *Element) SetBytes(e []byte) *Element {
 	return z
 }
 
-// SetBigInt sets z to v and returns z
+// SetBytesCanonical interprets e as the bytes of a big-endian Element.Bytes-byte integer.
+// If e is not a Element.Bytes-byte slice or encodes a value greater than or equal to q,
+// SetBytesCanonical returns an error and leaves z unchanged.
+//
+// Unlike SetBytes, no modular reduction is performed; this is useful when encodings must be unique.
+func (z *Element) SetBytesCanonical(e []byte) error {
+	if len(e) != Bytes {
+		return errors.New("invalid fr.Element encoding: expected fr.Bytes bytes")
+	}
+	var t Element
+	t[0] = binary.BigEndian.Uint64(e[24:32])
+	t[1] = binary.BigEndian.Uint64(e[16:24])
+	t[2] = binary.BigEndian.Uint64(e[8:16])
+	t[3] = binary.BigEndian.Uint64(e[0:8])
+	if !t.IsReduced() {
+		return errors.New("invalid fr.Element encoding: value is not smaller than the modulus")
+	}
+	*z = t
+	z.ToMont()
+	return nil
+}
+
+// IsCanonicalBytes returns true if buf is a big-endian fr.Bytes-byte integer strictly smaller than q,
+// that is, if SetBytesCanonical accepts it.
+func IsCanonicalBytes(buf []byte) bool {
+	if len(buf) != Bytes {
+		return false
+	}
+	var t Element
+	t[0] = binary.BigEndian.Uint64(buf[24:32])
+	t[1] = binary.BigEndian.Uint64(buf[16:24])
+	t[2] = binary.BigEndian.Uint64(buf[8:16])
+	t[3] = binary.BigEndian.Uint64(buf[0:8])
+	return t.smallerThanModulus()
+}
+
+// WriteTo writes the canonical big-endian encoding of z (see Bytes) to w.
+// It returns the number of bytes written, fr.Bytes on success.
+//
+// It implements io.WriterTo.
+func (z *Element) WriteTo(w io.Writer) (int64, error) {
+	b := z.Bytes()
+	n, err := w.Write(b[:])
+	return int64(n), err
+}
+
+// ReadFrom reads fr.Bytes bytes from r and sets z to the element they encode,
+// as SetBytesCanonical: non canonical encodings are rejected and leave z unchanged.
+// It returns the number of bytes read.
+//
+// It implements io.ReaderFrom.
+func (z *Element) ReadFrom(r io.Reader) (int64, error) {
+	var b [Bytes]byte
+	n, err := io.ReadFull(r, b[:])
+	if err != nil {
+		return int64(n), err
+	}
+	return int64(n), z.SetBytesCanonical(b[:])
+}
+
+// FromBytes hashes msg into a Element, using dst as a domain separation tag.
+//
+// It computes b₀ = SHA-256(I2OSP(len(dst), 1) ∥ dst ∥ msg), expands it into
+// bᵢ = SHA-256(b₀ ∥ I2OSP(i, 1)) for i = 1, 2, ... until Bytes+16 bytes are obtained,
+// and reduces the resulting big-endian integer modulo q. The 128 extra bits make
+// the output statistically indistinguishable from uniform.
+//
+// Unlike hash_to_field from RFC 9380, it is meant as a lightweight helper
+// (Fiat-Shamir challenges, randomness derivation). It panics if len(dst) > 255.
+func FromBytes(dst, msg []byte) Element {
+	if len(dst) > 255 {
+		panic("invalid domain separation tag (>255 bytes)")
+	}
+	const wideLen = Bytes + 16
+
+	h := sha256.New()
+	h.Write([]byte{uint8(len(dst))})
+	h.Write(dst)
+	h.Write(msg)
+	b0 := h.Sum(nil)
+
+	wide := make([]byte, 0, wideLen+sha256.Size)
+	for i := 1; len(wide) < wideLen; i++ {
+		h.Reset()
+		h.Write(b0)
+		h.Write([]byte{uint8(i)})
+		wide = h.Sum(wide)
+	}
+
+	var res Element
+	res.SetBytes(wide[:wideLen])
+	return res
+}
+
+// hashToScalarDST is the domain separation tag of HashToScalar
+const hashToScalarDST = "gnark-crypto-HashToScalar"
+
+// HashToScalar hashes parts into a scalar, e.g. a Schnorr challenge c = H(R ∥ pubkey ∥ msg) mod r.
+//
+// Each part is prefixed with its length as a big-endian uint64, so that the encoding is unambiguous:
+// ("ab", "c") and ("a", "bc") hash differently, as do the parts in a different order.
+// The result is FromBytes("gnark-crypto-HashToScalar", len(p₀) ∥ p₀ ∥ len(p₁) ∥ p₁ ∥ ...);
+// protocols are expected to pass their own domain separation tag as the first part.
+func HashToScalar(parts ...[]byte) Element {
+	size := 0
+	for _, p := range parts {
+		size += 8 + len(p)
+	}
+	msg := make([]byte, 0, size)
+	var buf [8]byte
+	for _, p := range parts {
+		binary.BigEndian.PutUint64(buf[:], uint64(len(p)))
+		msg = append(msg, buf[:]...)
+		msg = append(msg, p...)
+	}
+	return FromBytes([]byte(hashToScalarDST), msg)
+}
+
+// SetBigInt sets z to v (regular form) and returns z
+//
+// v is reduced modulo q if it is negative or ⩾ q. See ToBigIntRegular for the inverse operation.
 func (z *Element) SetBigInt(v *big.Int) *Element {
 	z.SetZero()
 
@@ -1009,11 +1226,18 @@ func (z *Element) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// EulerCriterion sets z = x^((q-1)/2) (mod q), the exponentiation of Euler's criterion
+// used by Legendre, and returns z. z is 1 if x is a non-zero square, -1 if x is not a
+// square and 0 if x is 0.
+func (z *Element) EulerCriterion(x *Element) *Element {
+	return z.expByLegendreExp(*x)
+}
+
 // Legendre returns the Legendre symbol of z (either +1, -1, or 0.)
 func (z *Element) Legendre() int {
 	var l Element
 	// z^((q-1)/2)
-	l.expByLegendreExp(*z)
+	l.EulerCriterion(z)
 
 	if l.IsZero() {
 		return 0
@@ -1026,6 +1250,26 @@ func (z *Element) Legendre() int {
 	return -1
 }
 
+// BatchSqrt returns the square roots of the elements of in (as Sqrt), and for each element
+// whether it is a square; the roots of the non-squares are set to 0.
+//
+// The square roots are computed in parallel.
+func BatchSqrt(in []Element) ([]Element, []bool) {
+	res := make([]Element, len(in))
+	isSquare := make([]bool, len(in))
+	parallel.Execute(len(in), func(start, end int) {
+		for i := start; i < end; i++ {
+			isSquare[i] = res[i].Sqrt(&in[i]) != nil
+		}
+	})
+	return res, isSquare
+}
+
+// IsSquare returns true if z is a square (quadratic residue) mod q, including 0
+func (z *Element) IsSquare() bool {
+	return z.Legendre() != -1
+}
+
 // Sqrt z = √x (mod q)
 // if the square root doesn't exist (x is not a square mod q)
 // Sqrt leaves z unchanged and returns nil
@@ -1094,6 +1338,39 @@ func (z *Element) Sqrt(x *Element) *Element {
 	}
 }
 
+// TwoAdicity returns the largest e such that 2ᵉ divides q-1
+func TwoAdicity() uint64 {
+	return 47
+}
+
+// Generator returns a primitive 2^logSize-th root of unity
+//
+// The roots are consistent across sizes: Generator(logSize-1) = Generator(logSize)².
+// It panics if logSize is negative or larger than TwoAdicity().
+func Generator(logSize int) Element {
+	if logSize < 0 || uint64(logSize) > TwoAdicity() {
+		panic("logSize is out of range: the required root of unity does not exist")
+	}
+	// primitive 2^TwoAdicity-th root of unity
+	res := Element{
+		12646347781564978760,
+		6783048705277173164,
+		268534165941069093,
+		1121515446318641358,
+	}
+	for i := uint64(logSize); i < TwoAdicity(); i++ {
+		res.Square(&res)
+	}
+	return res
+}
+
+// MultiplicativeGenerator returns a generator of the multiplicative group of the field
+//
+// Generator(TwoAdicity()) is MultiplicativeGenerator()^((q-1)/2^TwoAdicity()).
+func MultiplicativeGenerator() Element {
+	return NewElement(22)
+}
+
 const (
 	k               = 32 // word size / 2
 	signBitSelector = uint64(1) << 63
@@ -17,6 +17,7 @@
 package fft
 
 import (
+	"math/big"
 	"math/bits"
 	"runtime"
 
@@ -37,13 +38,24 @@ const (
 // parallelize threshold for a single butterfly op, if the fft stage is not parallelized already
 const butterflyThreshold = 16
 
+// below serialThreshold elements, FFT and FFTInverse run in the calling go routine:
+// spawning go routines would cost more than it saves, and would allocate.
+const serialThreshold = 1 << 8
+
 // FFT computes (recursively) the discrete Fourier transform of a and stores the result in a
 // if decimation == DIT (decimation in time), the input must be in bit-reversed order
 // if decimation == DIF (decimation in frequency), the output will be in bit-reversed order
 // if coset if set, the FFT(a) returns the evaluation of a on a coset.
+//
+// The transform is done in place and uses no scratch buffer; the domain is only read,
+// so FFT and FFTInverse may be called concurrently on distinct slices with the same domain.
+// Small inputs (up to 256 elements) are processed without spawning go routines nor allocating.
 func (domain *Domain) FFT(a []fr.Element, decimation Decimation, coset ...bool) {
 
 	numCPU := uint64(runtime.NumCPU())
+	if len(a) <= serialThreshold {
+		numCPU = 1
+	}
 
 	_coset := false
 	if len(coset) > 0 {
@@ -52,19 +64,21 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, coset ...bool)
 
 	// if coset != 0, scale by coset table
 	if _coset {
-		scale := func(cosetTable []fr.Element) {
+		cosetTable := domain.CosetTable
+		if decimation == DIT {
+			cosetTable = domain.CosetTableReversed
+		}
+		if numCPU <= 1 {
+			for i := 0; i < len(a); i++ {
+				a[i].Mul(&a[i], &cosetTable[i])
+			}
+		} else {
 			parallel.Execute(len(a), func(start, end int) {
 				for i := start; i < end; i++ {
 					a[i].Mul(&a[i], &cosetTable[i])
 				}
 			})
 		}
-		if decimation == DIT {
-			scale(domain.CosetTableReversed)
-
-		} else {
-			scale(domain.CosetTable)
-		}
 	}
 
 	// find the stage where we should stop spawning go routines in our recursive calls
@@ -89,9 +103,14 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, coset ...bool)
 // if decimation == DIF (decimation in frequency), the output will be in bit-reversed order
 // coset sets the shift of the fft (0 = no shift, standard fft)
 // len(a) must be a power of 2, and w must be a len(a)th root of unity in field F.
+//
+// See FFT for the memory and concurrency behavior.
 func (domain *Domain) FFTInverse(a []fr.Element, decimation Decimation, coset ...bool) {
 
 	numCPU := uint64(runtime.NumCPU())
+	if len(a) <= serialThreshold {
+		numCPU = 1
+	}
 
 	_coset := false
 	if len(coset) > 0 {
@@ -115,6 +134,12 @@ func (domain *Domain) FFTInverse(a []fr.Element, decimation Decimation, coset ..
 
 	// scale by CardinalityInv
 	if !_coset {
+		if numCPU <= 1 {
+			for i := 0; i < len(a); i++ {
+				a[i].Mul(&a[i], &domain.CardinalityInv)
+			}
+			return
+		}
 		parallel.Execute(len(a), func(start, end int) {
 			for i := start; i < end; i++ {
 				a[i].Mul(&a[i], &domain.CardinalityInv)
@@ -123,22 +148,117 @@ func (domain *Domain) FFTInverse(a []fr.Element, decimation Decimation, coset ..
 		return
 	}
 
-	scale := func(cosetTable []fr.Element) {
-		parallel.Execute(len(a), func(start, end int) {
-			for i := start; i < end; i++ {
-				a[i].Mul(&a[i], &cosetTable[i]).
-					Mul(&a[i], &domain.CardinalityInv)
-			}
-		})
-	}
+	cosetTable := domain.CosetTableInvReversed
 	if decimation == DIT {
-		scale(domain.CosetTableInv)
+		cosetTable = domain.CosetTableInv
+	}
+	if numCPU <= 1 {
+		for i := 0; i < len(a); i++ {
+			a[i].Mul(&a[i], &cosetTable[i]).
+				Mul(&a[i], &domain.CardinalityInv)
+		}
 		return
 	}
+	parallel.Execute(len(a), func(start, end int) {
+		for i := start; i < end; i++ {
+			a[i].Mul(&a[i], &cosetTable[i]).
+				Mul(&a[i], &domain.CardinalityInv)
+		}
+	})
+
+}
+
+// LagrangeToMonomial converts in place a, the evaluations of a polynomial on the domain
+// (in natural order), into the coefficients of the polynomial in the monomial basis (in natural order).
+// len(a) must be domain.Cardinality.
+func (domain *Domain) LagrangeToMonomial(a []fr.Element) {
+	domain.FFTInverse(a, DIF)
+	BitReverse(a)
+}
+
+// MonomialToLagrange converts in place a, the coefficients of a polynomial in the monomial basis
+// (in natural order), into its evaluations on the domain (in natural order).
+// len(a) must be domain.Cardinality.
+func (domain *Domain) MonomialToLagrange(a []fr.Element) {
+	domain.FFT(a, DIF)
+	BitReverse(a)
+}
+
+// Forward computes in place the evaluations on the domain of the polynomial whose coefficients
+// (in the monomial basis, natural order) are given in a. The evaluations are in bit-reversed order,
+// which is what Inverse expects: Inverse(Forward(a)) == a.
+//
+// It is FFT(a, DIF) and saves the caller from pairing the decimations; use MonomialToLagrange
+// to get the evaluations in natural order.
+func (domain *Domain) Forward(a []fr.Element) {
+	domain.FFT(a, DIF)
+}
+
+// Inverse is the inverse of Forward: it interpolates in place evaluations on the domain,
+// given in bit-reversed order, into coefficients in the monomial basis (natural order).
+//
+// It is FFTInverse(a, DIT); use LagrangeToMonomial for evaluations in natural order.
+func (domain *Domain) Inverse(a []fr.Element) {
+	domain.FFTInverse(a, DIT)
+}
+
+// DivideByVanishing divides p, given in the monomial basis, by Xⁿ-1, the vanishing polynomial of the domain
+// (n = domain.Cardinality).
+//
+// ok is true iff the remainder is zero, i.e. iff p vanishes on the domain; in that case quotient holds
+// the len(p)-n coefficients of p/(Xⁿ-1) (none if len(p) ⩽ n). Otherwise quotient is nil.
+//
+// The remainder is obtained by folding p modulo Xⁿ-1. The quotient is computed by pointwise division
+// on a coset of a larger domain, where Xⁿ-1 doesn't vanish.
+func (domain *Domain) DivideByVanishing(p []fr.Element) (quotient []fr.Element, ok bool) {
+	n := int(domain.Cardinality)
+
+	// remainder of p modulo Xⁿ-1
+	remainder := make([]fr.Element, n)
+	for i := 0; i < len(p); i++ {
+		remainder[i%n].Add(&remainder[i%n], &p[i])
+	}
+	for i := 0; i < n; i++ {
+		if !remainder[i].IsZero() {
+			return nil, false
+		}
+	}
+	if len(p) <= n {
+		return []fr.Element{}, true
+	}
+
+	// evaluate p on the coset g⋅H' of a domain H' of size m ⩾ len(p) (output in bit reversed order)
+	m := ecc.NextPowerOfTwo(uint64(len(p)))
+	domainBig := NewDomain(m)
+	res := make([]fr.Element, m)
+	copy(res, p)
+	domainBig.FFT(res, DIF, true)
+
+	// on g⋅H', Xⁿ-1 takes the m/n values gⁿ⋅ρᵗ-1 where ρ = ωⁿ is of order m/n
+	ratio := m / uint64(n)
+	zInv := make([]fr.Element, ratio)
+	var rho, acc, one fr.Element
+	var bn big.Int
+	bn.SetUint64(uint64(n))
+	rho.Exp(domainBig.Generator, &bn)
+	acc.Exp(domainBig.FrMultiplicativeGen, &bn)
+	one.SetOne()
+	for t := uint64(0); t < ratio; t++ {
+		zInv[t].Sub(&acc, &one)
+		acc.Mul(&acc, &rho)
+	}
+	zInv = fr.BatchInvert(zInv)
+
+	shift := 64 - uint64(bits.TrailingZeros64(m))
+	for i := uint64(0); i < m; i++ {
+		iRev := bits.Reverse64(i) >> shift
+		res[i].Mul(&res[i], &zInv[iRev%ratio])
+	}
 
-	// decimation == DIF
-	scale(domain.CosetTableInvReversed)
+	// back to the monomial basis
+	domainBig.FFTInverse(res, DIT, true)
 
+	return res[:len(p)-n], true
 }
 
 func difFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDone chan struct{}) {
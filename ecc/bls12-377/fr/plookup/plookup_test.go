@@ -18,9 +18,12 @@ package plookup
 
 import (
 	"math/big"
+	"reflect"
 	"testing"
 
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/kzg"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
 )
 
 func TestLookupVector(t *testing.T) {
@@ -118,6 +121,226 @@ func TestLookupTable(t *testing.T) {
 
 }
 
+// forgeLookupTables returns a proof of lookup of f in t which claims ts as the commitments
+// to the rows of t, as a dishonest prover would
+func forgeLookupTables(srs *kzg.SRS, f, t []Table, ts []kzg.Digest) (ProofLookupTables, error) {
+	proof, err := ProveLookupTables(srs, f, t)
+	if err != nil {
+		return proof, err
+	}
+	n := len(t[0])
+	lfs := make([][]fr.Element, len(f))
+	lts := make([][]fr.Element, len(t))
+	for i := range t {
+		lfs[i] = polynomial.PadWithLast(f[i], n)
+		lts[i] = polynomial.PadWithLast(t[i], n)
+	}
+	proof.ts = make([]kzg.Digest, len(ts))
+	copy(proof.ts, ts)
+	err = proof.proveFolded(srs, lfs, lts)
+	return proof, err
+}
+
+func TestLookupTableForgedCommitments(t *testing.T) {
+
+	srs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookupTable := make([]Table, 3)
+	otherTable := make([]Table, 3)
+	fTable := make([]Table, 3)
+	for i := 0; i < 3; i++ {
+		lookupTable[i] = make(Table, 8)
+		otherTable[i] = make(Table, 8)
+		fTable[i] = make(Table, 7)
+		for j := 0; j < 8; j++ {
+			lookupTable[i][j].SetUint64(uint64(2*i + j))
+			otherTable[i][j].SetUint64(uint64(100 + 2*i + j))
+		}
+		for j := 0; j < 7; j++ {
+			fTable[i][j].Set(&otherTable[i][(4*j+1)%8])
+		}
+	}
+
+	honest, err := ProveLookupTables(srs, fTable, lookupTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// f is in otherTable, not in lookupTable: claiming the commitments of lookupTable
+	// must not make the proof verify
+	proof, err := forgeLookupTables(srs, fTable, otherTable, honest.ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyLookupTables(srs, proof); err != ErrFoldedCommitment {
+		t.Fatal("expected ErrFoldedCommitment")
+	}
+}
+
+func TestLookupTableLagrange(t *testing.T) {
+
+	srs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookupTable := make([]Table, 3)
+	fTable := make([]Table, 3)
+	for i := 0; i < 3; i++ {
+		lookupTable[i] = make(Table, 8)
+		fTable[i] = make(Table, 7)
+		for j := 0; j < 8; j++ {
+			lookupTable[i][j].SetUint64(uint64(2*i + j))
+		}
+		for j := 0; j < 7; j++ {
+			fTable[i][j].Set(&lookupTable[i][(4*j+1)%8])
+		}
+	}
+
+	// the rows are padded to the domain of size 8
+	srsLagrange, err := srs.ToLagrange(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the proof verifies with the standard verifier, and is the one of the monomial path
+	proof, err := ProveLookupTables(srs, fTable, lookupTable, srsLagrange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyLookupTables(srs, proof); err != nil {
+		t.Fatal(err)
+	}
+	expected, err := ProveLookupTables(srs, fTable, lookupTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(proof, expected) {
+		t.Fatal("the Lagrange and monomial paths should produce the same proof")
+	}
+
+	// Lagrange SRS on the wrong domain
+	srsLagrange, err = srs.ToLagrange(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ProveLookupTables(srs, fTable, lookupTable, srsLagrange); err != ErrLagrangeSRSSize {
+		t.Fatal("expected ErrLagrangeSRSSize")
+	}
+}
+
+func TestLookupTablePrecomputed(t *testing.T) {
+
+	srs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookupTable := make([]Table, 3)
+	fTable := make([]Table, 3)
+	for i := 0; i < 3; i++ {
+		lookupTable[i] = make(Table, 8)
+		fTable[i] = make(Table, 7)
+		for j := 0; j < 8; j++ {
+			lookupTable[i][j].SetUint64(uint64(2*i + j))
+		}
+		for j := 0; j < 7; j++ {
+			fTable[i][j].Set(&lookupTable[i][(4*j+1)%8])
+		}
+	}
+
+	table, err := NewPrecomputedTable(srs, lookupTable, len(fTable[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// same result as the standard path
+	proof, err := ProveLookupTables(srs, fTable, lookupTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyLookupTables(srs, proof); err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyLookupTablesPrecomputed(srs, table, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	// a valid proof of lookup in another table is rejected
+	otherTable := make([]Table, 3)
+	for i := 0; i < 3; i++ {
+		otherTable[i] = make(Table, 8)
+		copy(otherTable[i], lookupTable[i])
+	}
+	otherTable[0][0].SetUint64(42)
+	proof, err = ProveLookupTables(srs, fTable, otherTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyLookupTables(srs, proof); err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyLookupTablesPrecomputed(srs, table, proof); err == nil {
+		t.Fatal("a proof of lookup in another table should be rejected")
+	}
+
+	// a proof of lookup in another table, carrying the commitments of the precomputed one
+	proof, err = forgeLookupTables(srs, fTable, otherTable, table.ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyLookupTablesPrecomputed(srs, table, proof); err != ErrFoldedCommitment {
+		t.Fatal("expected ErrFoldedCommitment")
+	}
+
+	// a proof with a different number of rows
+	if err = VerifyLookupTablesPrecomputed(srs, PrecomputedTable{ts: table.ts[:2]}, proof); err != ErrPrecomputedTable {
+		t.Fatal("expected ErrPrecomputedTable")
+	}
+
+	// wrong proof
+	fTable[0][0].SetRandom()
+	proof, err = ProveLookupTables(srs, fTable, lookupTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyLookupTablesPrecomputed(srs, table, proof); err == nil {
+		t.Fatal("verification of a wrong proof should fail")
+	}
+}
+
+func TestFindMissingRows(t *testing.T) {
+
+	lookupTable := make([]Table, 3)
+	fTable := make([]Table, 3)
+	for i := 0; i < 3; i++ {
+		lookupTable[i] = make(Table, 8)
+		fTable[i] = make(Table, 7)
+		for j := 0; j < 8; j++ {
+			lookupTable[i][j].SetUint64(uint64(2*i + j))
+		}
+		for j := 0; j < 7; j++ {
+			fTable[i][j].Set(&lookupTable[i][(4*j+1)%8])
+		}
+	}
+
+	// f is contained in t
+	if missing := FindMissingRows(fTable, lookupTable); len(missing) != 0 {
+		t.Fatalf("expected no missing rows, got %v", missing)
+	}
+
+	// f[:][2] and f[:][5] are not in t, even though each of their entries is in the matching t[i]
+	fTable[0][2].SetRandom()
+	fTable[1][5].Set(&lookupTable[1][0])
+	missing := FindMissingRows(fTable, lookupTable)
+	if !reflect.DeepEqual(missing, []int{2, 5}) {
+		t.Fatalf("expected missing rows [2 5], got %v", missing)
+	}
+}
+
 func BenchmarkPlookup(b *testing.B) {
 
 	srsSize := 1 << 15
@@ -19,7 +19,6 @@ package plookup
 import (
 	"crypto/sha256"
 	"errors"
-	"math/big"
 	"sort"
 
 	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
@@ -27,6 +26,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/kzg"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/permutation"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
 	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
 )
 
@@ -34,6 +34,8 @@ var (
 	ErrIncompatibleSize = errors.New("the tables in f and t are not of the same size")
 	ErrFoldedCommitment = errors.New("the folded commitment is malformed")
 	ErrNumberDigests    = errors.New("proof.ts and proof.fs are not of the same length")
+	ErrLagrangeSRSSize  = errors.New("the Lagrange SRS is not of the size of the domain of the rows")
+	ErrPrecomputedTable = errors.New("the proof does not have as many rows as the precomputed table")
 )
 
 // ProofLookupTables proofs that a list of tables
@@ -60,18 +62,16 @@ type ProofLookupTables struct {
 // that t[:][i] contains the i-th entry of the truth table, so t[0][i] XOR t[1][i] = t[2][i].
 //
 // The Table in f and t are supposed to be of the same size constant size.
-func ProveLookupTables(srs *kzg.SRS, f, t []Table) (ProofLookupTables, error) {
+//
+// Optionally, srsLagrange is the Lagrange form of srs on the domain of the rows (see
+// kzg.SRS.ToLagrange), in which case the rows are committed directly from their
+// evaluations, without converting them to the monomial basis.
+func ProveLookupTables(srs *kzg.SRS, f, t []Table, srsLagrange ...*kzg.SRSLagrange) (ProofLookupTables, error) {
 
 	// res
 	proof := ProofLookupTables{}
 	var err error
 
-	// hash function used for Fiat Shamir
-	hFunc := sha256.New()
-
-	// transcript to derive the challenge
-	fs := fiatshamir.NewTranscript(hFunc, "lambda")
-
 	// check the sizes
 	if len(f) != len(t) {
 		return proof, ErrIncompatibleSize
@@ -91,53 +91,66 @@ func ProveLookupTables(srs *kzg.SRS, f, t []Table) (ProofLookupTables, error) {
 
 	// commit to the tables in f and t
 	nbRows := len(t)
-	proof.fs = make([]kzg.Digest, nbRows)
-	proof.ts = make([]kzg.Digest, nbRows)
 	_nbColumns := len(f[0]) + 1
 	if _nbColumns < len(t[0]) {
 		_nbColumns = len(t[0])
 	}
 	d := fft.NewDomain(uint64(_nbColumns))
 	nbColumns := d.Cardinality
+	lagrange := len(srsLagrange) > 0 && srsLagrange[0] != nil
+	if lagrange && uint64(len(srsLagrange[0].G1)) != nbColumns {
+		return proof, ErrLagrangeSRSSize
+	}
 	lfs := make([][]fr.Element, nbRows)
-	cfs := make([][]fr.Element, nbRows)
 	lts := make([][]fr.Element, nbRows)
-	cts := make([][]fr.Element, nbRows)
-
 	for i := 0; i < nbRows; i++ {
+		lfs[i] = polynomial.PadWithLast(f[i], int(nbColumns))
+		lts[i] = polynomial.PadWithLast(t[i], int(nbColumns))
+	}
 
-		cfs[i] = make([]fr.Element, nbColumns)
-		lfs[i] = make([]fr.Element, nbColumns)
-		copy(cfs[i], f[i])
-		copy(lfs[i], f[i])
-		for j := len(f[i]); j < int(nbColumns); j++ {
-			cfs[i][j] = f[i][len(f[i])-1]
-			lfs[i][j] = f[i][len(f[i])-1]
+	if lagrange {
+		if proof.fs, err = kzg.BatchCommitLagrange(lfs, srsLagrange[0], 0); err != nil {
+			return proof, err
 		}
-		d.FFTInverse(cfs[i], fft.DIF)
-		fft.BitReverse(cfs[i])
-		proof.fs[i], err = kzg.Commit(cfs[i], srs)
-		if err != nil {
+		if proof.ts, err = kzg.BatchCommitLagrange(lts, srsLagrange[0], 0); err != nil {
 			return proof, err
 		}
-
-		cts[i] = make([]fr.Element, nbColumns)
-		lts[i] = make([]fr.Element, nbColumns)
-		copy(cts[i], t[i])
-		copy(lts[i], t[i])
-		for j := len(t[i]); j < int(d.Cardinality); j++ {
-			cts[i][j] = t[i][len(t[i])-1]
-			lts[i][j] = t[i][len(t[i])-1]
+	} else {
+		cfs := make([][]fr.Element, nbRows)
+		cts := make([][]fr.Element, nbRows)
+		for i := 0; i < nbRows; i++ {
+			cfs[i] = make([]fr.Element, nbColumns)
+			copy(cfs[i], lfs[i])
+			d.LagrangeToMonomial(cfs[i])
+
+			cts[i] = make([]fr.Element, nbColumns)
+			copy(cts[i], lts[i])
+			d.LagrangeToMonomial(cts[i])
+		}
+		if proof.fs, err = kzg.BatchCommit(cfs, srs, 0); err != nil {
+			return proof, err
 		}
-		d.FFTInverse(cts[i], fft.DIF)
-		fft.BitReverse(cts[i])
-		proof.ts[i], err = kzg.Commit(cts[i], srs)
-		if err != nil {
+		if proof.ts, err = kzg.BatchCommit(cts, srs, 0); err != nil {
 			return proof, err
 		}
 	}
 
+	err = proof.proveFolded(srs, lfs, lts)
+	return proof, err
+}
+
+// proveFolded folds the padded rows lfs and lts with a challenge binding proof.fs and
+// proof.ts, and sets the permutation and lookup proofs of the folded tables.
+func (proof *ProofLookupTables) proveFolded(srs *kzg.SRS, lfs, lts [][]fr.Element) error {
+
+	// hash function used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenge
+	fs := fiatshamir.NewTranscript(hFunc, "lambda")
+
 	// fold f and t
+	nbRows := len(lts)
 	comms := make([]*kzg.Digest, 2*nbRows)
 	for i := 0; i < nbRows; i++ {
 		comms[i] = new(kzg.Digest)
@@ -147,80 +160,75 @@ func ProveLookupTables(srs *kzg.SRS, f, t []Table) (ProofLookupTables, error) {
 	}
 	lambda, err := deriveRandomness(&fs, "lambda", comms...)
 	if err != nil {
-		return proof, err
-	}
-	foldedf := make(Table, nbColumns)
-	foldedt := make(Table, nbColumns)
-	for i := 0; i < int(nbColumns); i++ {
-		for j := nbRows - 1; j >= 0; j-- {
-			foldedf[i].Mul(&foldedf[i], &lambda).
-				Add(&foldedf[i], &lfs[j][i])
-			foldedt[i].Mul(&foldedt[i], &lambda).
-				Add(&foldedt[i], &lts[j][i])
-		}
+		return err
 	}
+	foldedf := Table(polynomial.FoldVectors(lfs, lambda))
+	foldedt := Table(polynomial.FoldVectors(lts, lambda))
 
 	// generate a proof of permutation of the foldedt and sort(foldedt)
-	foldedtSorted := make(Table, nbColumns)
+	foldedtSorted := make(Table, len(foldedt))
 	copy(foldedtSorted, foldedt)
 	sort.Sort(foldedtSorted)
 	proof.permutationProof, err = permutation.Prove(srs, foldedt, foldedtSorted)
 	if err != nil {
-		return proof, err
+		return err
 	}
 
 	// call plookupVector, on foldedf[:len(foldedf)-1] to ensure that the domain size
 	// in ProveLookupVector is the same as d's
 	proof.foldedProof, err = ProveLookupVector(srs, foldedf[:len(foldedf)-1], foldedt)
 
-	return proof, err
+	return err
 }
 
 // VerifyLookupTables verifies that a ProofLookupTables proof is correct.
 func VerifyLookupTables(srs *kzg.SRS, proof ProofLookupTables) error {
 
+	// check that the number of digests is the same
+	if len(proof.fs) != len(proof.ts) {
+		return ErrNumberDigests
+	}
+
+	return verifyLookupTables(srs, proof, proof.ts)
+}
+
+// verifyLookupTables verifies proof, taking ts as the commitments to the rows of t
+// (proof.ts is not read).
+func verifyLookupTables(srs *kzg.SRS, proof ProofLookupTables, ts []kzg.Digest) error {
+
 	// hash function used for Fiat Shamir
 	hFunc := sha256.New()
 
 	// transcript to derive the challenge
 	fs := fiatshamir.NewTranscript(hFunc, "lambda")
 
-	// check that the number of digests is the same
-	if len(proof.fs) != len(proof.ts) {
-		return ErrNumberDigests
-	}
-
 	// fold the commitments fs and ts
 	nbRows := len(proof.fs)
 	comms := make([]*kzg.Digest, 2*nbRows)
 	for i := 0; i < nbRows; i++ {
 		comms[i] = &proof.fs[i]
-		comms[i+nbRows] = &proof.ts[i]
+		comms[i+nbRows] = &ts[i]
 	}
 	lambda, err := deriveRandomness(&fs, "lambda", comms...)
 	if err != nil {
 		return err
 	}
 
-	// fold the commitments of the rows of t and f
-	var comf, comt kzg.Digest
-	comf.Set(&proof.fs[nbRows-1])
-	comt.Set(&proof.ts[nbRows-1])
-	var blambda big.Int
-	lambda.ToBigIntRegular(&blambda)
-	for i := nbRows - 2; i >= 0; i-- {
-		comf.ScalarMultiplication(&comf, &blambda).
-			Add(&comf, &proof.fs[i])
-		comt.ScalarMultiplication(&comt, &blambda).
-			Add(&comt, &proof.ts[i])
-	}
+	// fold the commitments of the rows of f and t
+	comf := bls12377.FoldPointsG1(proof.fs, lambda)
+	comt := bls12377.FoldPointsG1(ts, lambda)
 
 	// check that the folded commitment of the fs correspond to foldedProof.f
 	if !comf.Equal(&proof.foldedProof.f) {
 		return ErrFoldedCommitment
 	}
 
-	// check that the folded commitment of the ts is a permutation of proof.FoldedProof.t
+	// check that the folded commitment of the ts is a permutation of proof.foldedProof.t
+	// (the folded table sorted): the permutation proof is on (folded ts, foldedProof.t)
+	t1, t2 := proof.permutationProof.Commitments()
+	if !comt.Equal(&t1) || !proof.foldedProof.t.Equal(&t2) {
+		return ErrFoldedCommitment
+	}
 	err = permutation.Verify(srs, proof.permutationProof)
 	if err != nil {
 		return err
@@ -230,6 +238,99 @@ func VerifyLookupTables(srs *kzg.SRS, proof ProofLookupTables) error {
 	return VerifyLookupVector(srs, proof.foldedProof)
 }
 
+// PrecomputedTable stores the commitments to the rows of a fixed table t, so that a
+// verifier checking many proofs against the same t commits to it once.
+type PrecomputedTable struct {
+	ts []kzg.Digest
+}
+
+// NewPrecomputedTable commits to the rows of t the way ProveLookupTables does, for
+// lookups of tables f whose rows have sizeF entries (the rows are padded to a domain
+// which depends on both sizes).
+func NewPrecomputedTable(srs *kzg.SRS, t []Table, sizeF int) (PrecomputedTable, error) {
+
+	var res PrecomputedTable
+	if len(t) == 0 {
+		return res, ErrIncompatibleSize
+	}
+	for i := 1; i < len(t); i++ {
+		if len(t[i]) != len(t[0]) {
+			return res, ErrIncompatibleSize
+		}
+	}
+
+	_nbColumns := sizeF + 1
+	if _nbColumns < len(t[0]) {
+		_nbColumns = len(t[0])
+	}
+	d := fft.NewDomain(uint64(_nbColumns))
+	cts := make([][]fr.Element, len(t))
+	for i := 0; i < len(t); i++ {
+		cts[i] = polynomial.PadWithLast(t[i], int(d.Cardinality))
+		d.LagrangeToMonomial(cts[i])
+	}
+
+	var err error
+	res.ts, err = kzg.BatchCommit(cts, srs, 0)
+	return res, err
+}
+
+// VerifyLookupTablesPrecomputed verifies that a ProofLookupTables proof is correct, and
+// that it is a proof of lookup in the precomputed table.
+//
+// The commitments to the rows of t are taken from table, not from the proof: they are folded
+// and checked against the permutation argument as in VerifyLookupTables, so proof.ts is not read.
+func VerifyLookupTablesPrecomputed(srs *kzg.SRS, table PrecomputedTable, proof ProofLookupTables) error {
+
+	if len(proof.fs) != len(table.ts) {
+		return ErrPrecomputedTable
+	}
+
+	return verifyLookupTables(srs, proof, table.ts)
+}
+
+// FindMissingRows returns the indices i for which f[:][i] is not one of the t[:][j].
+//
+// It is a debugging helper for ProveLookupTables (for instance to find why VerifyLookupTables fails),
+// and is not part of the proof system. f and t must contain the same number of Table,
+// and the Table in f (resp. t) must have the same size; FindMissingRows panics otherwise.
+func FindMissingRows(f, t []Table) []int {
+	if len(f) != len(t) {
+		panic(ErrIncompatibleSize)
+	}
+	if len(f) == 0 {
+		return nil
+	}
+	for i := 1; i < len(f); i++ {
+		if len(f[i]) != len(f[0]) || len(t[i]) != len(t[0]) {
+			panic(ErrIncompatibleSize)
+		}
+	}
+
+	// key returns the concatenation of the canonical encodings of c[:][i]
+	key := func(c []Table, i int) string {
+		buf := make([]byte, 0, len(c)*fr.Bytes)
+		for k := 0; k < len(c); k++ {
+			b := c[k][i].Bytes()
+			buf = append(buf, b[:]...)
+		}
+		return string(buf)
+	}
+
+	entries := make(map[string]struct{}, len(t[0]))
+	for j := 0; j < len(t[0]); j++ {
+		entries[key(t, j)] = struct{}{}
+	}
+
+	var missing []int
+	for i := 0; i < len(f[0]); i++ {
+		if _, ok := entries[key(f, i)]; !ok {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
 // TODO put that in fiat-shamir package
 func deriveRandomness(fs *fiatshamir.Transcript, challenge string, points ...*bls12377.G1Affine) (fr.Element, error) {
 
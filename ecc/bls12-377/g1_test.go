@@ -23,6 +23,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/prop"
@@ -70,6 +71,111 @@ func TestG1AffineEndomorphism(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestG1AffineCofactor(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+	if ScalarFieldModulus().Cmp(fr.Modulus()) != 0 || BaseFieldModulus().Cmp(fp.Modulus()) != 0 {
+		t.Fatal("moduli don't match fr.Modulus() and fp.Modulus()")
+	}
+
+	var order big.Int
+	order.Mul(CofactorG1(), ScalarFieldModulus())
+
+	properties.Property("[BLS12-377] [CofactorG1⋅r] should clear any point of the curve", prop.ForAll(
+		func(a fp.Element) bool {
+			// find a point on the curve, not necessarily in the r-torsion
+			var p G1Affine
+			var one, rhs fp.Element
+			one.SetOne()
+			p.X = a
+			for {
+				rhs.Square(&p.X).Mul(&rhs, &p.X).Add(&rhs, &bCurveCoeff)
+				if rhs.Legendre() != -1 {
+					break
+				}
+				p.X.Add(&p.X, &one)
+			}
+			p.Y.Sqrt(&rhs)
+			if !p.IsOnCurve() {
+				return false
+			}
+
+			var res, cleared G1Jac
+			res.FromAffine(&p)
+			cleared.mulWindowed(&res, CofactorG1())
+			res.mulWindowed(&res, &order)
+			return res.Z.IsZero() && cleared.IsInSubGroup()
+		},
+		GenFp(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestGLVBasis(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	basis := GLVBasis()
+	var res, buf [2]big.Int
+
+	properties.Property("[BLS12-377] GLV splitting should recombine to the scalar mod r", prop.ForAll(
+		func(a fr.Element) bool {
+			var s, _s big.Int
+			a.ToBigIntRegular(&s)
+			ecc.SplitScalarInto(&res, &s, &basis, &buf)
+			_s.Mul(&res[1], &lambdaGLV).Add(&_s, &res[0]).Sub(&_s, &s)
+			_s.Mod(&_s, fr.Modulus())
+			return _s.Sign() == 0
+		},
+		GenFr(),
+	))
+
+	properties.Property("[BLS12-377] GLVSplit should recombine to the scalar mod r, accounting for the signs", prop.ForAll(
+		func(a fr.Element) bool {
+			var s big.Int
+			a.ToBigIntRegular(&s)
+			k1, k2, sign1, sign2 := GLVSplit(&s)
+
+			// the half-scalars are about half the size of r
+			var _k1, _k2 big.Int
+			k1.ToBigIntRegular(&_k1)
+			k2.ToBigIntRegular(&_k2)
+			if _k1.BitLen() > fr.Bits/2+2 || _k2.BitLen() > fr.Bits/2+2 {
+				return false
+			}
+
+			if sign1 {
+				k1.Neg(&k1)
+			}
+			if sign2 {
+				k2.Neg(&k2)
+			}
+			var lambda, res fr.Element
+			lambda.SetBigInt(&lambdaGLV)
+			res.Mul(&k2, &lambda).Add(&res, &k1)
+			return res.Equal(&a)
+		},
+		GenFr(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
 func TestG1AffineIsOnCurve(t *testing.T) {
 	t.Parallel()
 	parameters := gopter.DefaultTestParameters()
@@ -105,6 +211,23 @@ func TestG1AffineIsOnCurve(t *testing.T) {
 		GenFp(),
 	))
 
+	properties.Property("[BLS12-377] IsOnCurve in affine and Jacobian coordinates should agree", prop.ForAll(
+		func(a fp.Element) bool {
+			var op1, op2, inf G1Affine
+			var jac G1Jac
+			op1.FromJacobian(&g1Gen)
+			op2.Set(&op1)
+			op2.Y.Mul(&op2.Y, &a)
+			ok := true
+			for _, p := range []*G1Affine{&op1, &op2, &inf} {
+				jac.FromAffine(p)
+				ok = ok && p.IsOnCurve() == jac.IsOnCurve()
+			}
+			return ok && inf.IsOnCurve()
+		},
+		GenFp(),
+	))
+
 	properties.Property("[BLS12-377] IsInSubGroup and MulBy subgroup order should be the same", prop.ForAll(
 		func(a fp.Element) bool {
 			var op1, op2 G1Jac
@@ -140,6 +263,28 @@ func TestG1AffineConversions(t *testing.T) {
 		GenFp(),
 	))
 
+	properties.Property("[BLS12-377] XAffine should match the x-coordinate of FromJacobian", prop.ForAll(
+		func(a fp.Element) bool {
+			g := fuzzG1Jac(&g1Gen, a)
+			var op1 G1Affine
+			op1.FromJacobian(&g)
+			x := g.XAffine()
+			return x.Equal(&op1.X) && x.Equal(&g1Gen.X)
+		},
+		GenFp(),
+	))
+
+	properties.Property("[BLS12-377] XAffine of infinity should be 0", prop.ForAll(
+		func(a fp.Element) bool {
+			var inf G1Jac
+			inf.Set(&g1Infinity)
+			inf.X.Mul(&inf.X, &a)
+			x := inf.XAffine()
+			return x.IsZero()
+		},
+		GenFp(),
+	))
+
 	properties.Property("[BLS12-377] Affine representation should be independent of a Extended Jacobian representative", prop.ForAll(
 		func(a fp.Element) bool {
 			var g g1JacExtended
@@ -228,7 +373,9 @@ func TestG1AffineConversions(t *testing.T) {
 			op1.FromJacobian(&g1)
 			op2.FromJacobian(&g2)
 			baseTableAff := BatchJacobianToAffineG1([]G1Jac{g1, g2})
-			return op1.Equal(&baseTableAff[0]) && op2.Equal(&baseTableAff[1])
+			serialAff := BatchJacobianToAffineG1([]G1Jac{g1, g2}, 1)
+			return op1.Equal(&baseTableAff[0]) && op2.Equal(&baseTableAff[1]) &&
+				op1.Equal(&serialAff[0]) && op2.Equal(&serialAff[1])
 		},
 		GenFp(),
 		GenFp(),
@@ -237,6 +384,39 @@ func TestG1AffineConversions(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestBatchJacobianToAffineG1Chunked(t *testing.T) {
+	t.Parallel()
+
+	const nbPoints = 100
+	points := make([]G1Jac, nbPoints)
+	points[0].Set(&g1Gen)
+	for i := 1; i < nbPoints; i++ {
+		points[i].Set(&points[i-1]).AddMixed(&g1GenAff)
+	}
+	// include the point at infinity
+	points[5].Set(&g1Infinity)
+	points[nbPoints-1].Set(&g1Infinity)
+
+	expected := BatchJacobianToAffineG1(points)
+	for _, chunkSize := range []int{0, 1, 3, 7, nbPoints - 1, nbPoints, nbPoints + 1} {
+		for _, nbTasks := range []int{1, 4} {
+			result := BatchJacobianToAffineG1Chunked(points, chunkSize, nbTasks)
+			if len(result) != nbPoints {
+				t.Fatal("invalid number of points")
+			}
+			for i := 0; i < nbPoints; i++ {
+				if !result[i].Equal(&expected[i]) {
+					t.Fatalf("chunkSize=%d, nbTasks=%d: point %d mismatch", chunkSize, nbTasks, i)
+				}
+			}
+		}
+	}
+
+	if len(BatchJacobianToAffineG1Chunked(nil, 0)) != 0 {
+		t.Fatal("converting no points should return no points")
+	}
+}
+
 func TestG1AffineOps(t *testing.T) {
 	t.Parallel()
 	parameters := gopter.DefaultTestParameters()
@@ -393,6 +573,220 @@ func TestG1AffineOps(t *testing.T) {
 		genScalar,
 	))
 
+	properties.Property("[BLS12-377] GLV and Double and Add should output the same result on small scalars", prop.ForAll(
+		func(s fr.Element) bool {
+
+			var r, mask, small big.Int
+			var op1, op2 G1Jac
+			s.ToBigIntRegular(&r)
+			for _, nbBits := range []uint{0, 1, 2, 3, 8, 17, 63, 64, 65, 100} {
+				mask.Lsh(big.NewInt(1), nbBits).Sub(&mask, big.NewInt(1))
+				small.And(&r, &mask)
+				op1.mulWindowed(&g1Gen, &small)
+				op2.mulGLV(&g1Gen, &small)
+				if !op1.Equal(&op2) {
+					return false
+				}
+			}
+			return true
+
+		},
+		genScalar,
+	))
+
+	properties.Property("[BLS12-377] ScalarMulChain should output the same result as chained ScalarMultiplication", prop.ForAll(
+		func(s1, s2 fr.Element) bool {
+
+			var r1, r2 big.Int
+			var op1, op2 G1Affine
+			s1.ToBigIntRegular(&r1)
+			s2.ToBigIntRegular(&r2)
+			op1.ScalarMultiplication(&g1GenAff, &r1).
+				ScalarMultiplication(&op1, &r2)
+			op2.ScalarMulChain(&g1GenAff, &r1, &r2)
+			return op1.Equal(&op2)
+
+		},
+		genScalar,
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestG1AffineIsSmallOrder(t *testing.T) {
+	t.Parallel()
+
+	var infinity G1Affine
+	if !infinity.IsSmallOrder(1) {
+		t.Fatal("the point at infinity has order 1")
+	}
+
+	var g G1Affine
+	g.FromJacobian(&g1Gen)
+	if g.IsSmallOrder(1000) {
+		t.Fatal("the generator has order r")
+	}
+
+	// smallest prime factor of the cofactor, if it is small
+	q := 0
+	for _, c := range []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47} {
+		if new(big.Int).Mod(CofactorG1(), big.NewInt(int64(c))).Sign() == 0 {
+			q = c
+			break
+		}
+	}
+	if q == 0 {
+		t.Log("no small prime factor in the cofactor")
+		return
+	}
+
+	// m = h⋅r/qᵉ with q ∤ m: [m]p is in the q-torsion part of the group for any p of the curve,
+	// and multiplying it by q until the next multiple is 0 gives a point of order q.
+	// (The q-torsion part may not be cyclic, so [h⋅r/q]p can be 0 for all p.)
+	var m, bq, rem big.Int
+	bq.SetInt64(int64(q))
+	m.Mul(CofactorG1(), ScalarFieldModulus())
+	for {
+		var quo big.Int
+		quo.QuoRem(&m, &bq, &rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		m.Set(&quo)
+	}
+	var one, rhs fp.Element
+	one.SetOne()
+	var p G1Affine
+	p.X.SetOne()
+	for {
+		rhs.Square(&p.X).Mul(&rhs, &p.X).Add(&rhs, &bCurveCoeff)
+		if rhs.Legendre() == 1 {
+			p.Y.Sqrt(&rhs)
+			var _p, next G1Jac
+			_p.FromAffine(&p)
+			_p.mulWindowed(&_p, &m)
+			if !_p.Z.IsZero() {
+				for {
+					next.mulWindowed(&_p, &bq)
+					if next.Z.IsZero() {
+						break
+					}
+					_p.Set(&next)
+				}
+				p.FromJacobian(&_p)
+				break
+			}
+		}
+		p.X.Add(&p.X, &one)
+	}
+	var pr G1Jac
+	pr.FromAffine(&p)
+	pr.mulWindowed(&pr, ScalarFieldModulus())
+	if !p.IsOnCurve() || p.IsInfinity() || pr.Z.IsZero() {
+		t.Fatal("the constructed point should be on the curve, out of the r-torsion")
+	}
+	if !p.IsSmallOrder(q) {
+		t.Fatalf("the constructed point should have order %d", q)
+	}
+	if p.IsSmallOrder(q - 1) {
+		t.Fatalf("the constructed point should have order exactly %d", q)
+	}
+
+	// neither the small order point nor its sum with a point of the r-torsion are in the subgroup
+	if p.IsInSubGroup() {
+		t.Fatal("a point of small order should not be in the subgroup")
+	}
+	pr.Set(&g1Gen).AddMixed(&p)
+	if pr.IsInSubGroup() {
+		t.Fatal("the generator plus a point of small order should not be in the subgroup")
+	}
+}
+
+func TestG1AffineIsInSubGroupOrder3(t *testing.T) {
+	t.Parallel()
+
+	// the points (0, ±√b) have order 3, and are on the curve when b is a square
+	var p G1Affine
+	b := bCurveCoeff
+	if b.Legendre() != 1 {
+		t.Skip("b is not a square")
+	}
+	p.Y.Sqrt(&b)
+	if !p.IsOnCurve() || !p.IsSmallOrder(3) {
+		t.Fatal("(0, √b) should be a point of order 3")
+	}
+	if p.IsInSubGroup() {
+		t.Fatal("(0, √b) should not be in the subgroup")
+	}
+	var q G1Jac
+	q.Set(&g1Gen).AddMixed(&p)
+	if q.IsInSubGroup() {
+		t.Fatal("the generator plus (0, √b) should not be in the subgroup")
+	}
+}
+
+func TestG1AffineMulByCofactor(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-377] MulByCofactor should map any point of the curve in the r-torsion", prop.ForAll(
+		func(a fp.Element) bool {
+			// find a point on the curve, not necessarily in the r-torsion
+			var p G1Affine
+			var one, rhs fp.Element
+			one.SetOne()
+			p.X = a
+			for {
+				rhs.Square(&p.X).Mul(&rhs, &p.X).Add(&rhs, &bCurveCoeff)
+				if rhs.Legendre() != -1 {
+					break
+				}
+				p.X.Add(&p.X, &one)
+			}
+			p.Y.Sqrt(&rhs)
+
+			var res G1Affine
+			res.MulByCofactor(&p)
+			if !res.IsOnCurve() || !res.IsInSubGroup() {
+				return false
+			}
+
+			// ClearCofactor and MulByCofactor are both multiplications by a multiple of h
+			// (or an endomorphism) and commute
+			var cleared, left, right G1Affine
+			cleared.ClearCofactor(&p)
+			left.MulByCofactor(&cleared)
+			right.ClearCofactor(&res)
+			if !left.Equal(&right) {
+				return false
+			}
+			return true
+		},
+		GenFp(),
+	))
+
+	properties.Property("[BLS12-377] MulByCofactor should match the multiplication by h mod r in the r-torsion", prop.ForAll(
+		func(s fr.Element) bool {
+			var g, res, expected G1Affine
+			var sBig, h big.Int
+			s.ToBigIntRegular(&sBig)
+			g.ScalarMultiplication(&g1GenAff, &sBig)
+			res.MulByCofactor(&g)
+			h.Mod(CofactorG1(), fr.Modulus())
+			expected.ScalarMultiplication(&g, &h)
+			return res.Equal(&expected)
+		},
+		GenFr(),
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
@@ -483,12 +877,543 @@ func TestG1AffineBatchScalarMultiplication(t *testing.T) {
 		genScalar,
 	))
 
+	properties.Property("[BLS12-377] BatchScalarMultiplication and BatchScalarMul should not depend on nbTasks", prop.ForAll(
+		func(mixer fr.Element) bool {
+			var sampleScalars [nbSamples]fr.Element
+			bases := make([]G1Affine, nbSamples)
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+				bases[i-1].ScalarMultiplication(&g1GenAff, big.NewInt(int64(i)))
+			}
+
+			expected := BatchScalarMultiplicationG1(&g1GenAff, sampleScalars[:])
+			expectedMul := BatchScalarMulG1(bases, sampleScalars[:])
+			for _, nbTasks := range []int{1, 2, 3, nbSamples + 1} {
+				result := BatchScalarMultiplicationG1(&g1GenAff, sampleScalars[:], nbTasks)
+				resultMul := BatchScalarMulG1(bases, sampleScalars[:], nbTasks)
+				for i := 0; i < nbSamples; i++ {
+					if !result[i].Equal(&expected[i]) || !resultMul[i].Equal(&expectedMul[i]) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestG1AffineBatchScalarMultiplicationSmall(t *testing.T) {
+	t.Parallel()
+
+	// zero-length inputs
+	if res := BatchScalarMultiplicationG1(&g1GenAff, nil); res == nil || len(res) != 0 {
+		t.Fatal("BatchScalarMultiplication on no scalars should return an empty slice")
+	}
+	if res := BatchScalarMulG1(nil, nil); res == nil || len(res) != 0 {
+		t.Fatal("BatchScalarMul on no scalars should return an empty slice")
+	}
+
+	// single scalar, including zero
+	var scalars [3]fr.Element
+	scalars[0].SetRandom()
+	scalars[1].SetOne()
+	for i := range scalars {
+		var b big.Int
+		var expected G1Affine
+		expected.ScalarMultiplication(&g1GenAff, scalars[i].ToBigIntRegular(&b))
+
+		regular := scalars[i]
+		regular.FromMont()
+		res := BatchScalarMultiplicationG1(&g1GenAff, []fr.Element{regular})
+		if len(res) != 1 || !res[0].Equal(&expected) {
+			t.Fatal("BatchScalarMultiplication on a single scalar is incorrect")
+		}
+
+		res = BatchScalarMulG1([]G1Affine{g1GenAff}, scalars[i:i+1])
+		if len(res) != 1 || !res[0].Equal(&expected) {
+			t.Fatal("BatchScalarMul on a single scalar is incorrect")
+		}
+	}
+}
+
+func TestG1AffineBatchScalarMul(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = nbFuzzShort
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	const nbSamples = 10
+
+	properties.Property("[BLS12-377] BatchScalarMul should be consistent with individual scalar multiplications", prop.ForAll(
+		func(mixer fr.Element) bool {
+			var scalars [nbSamples]fr.Element
+			var bases [nbSamples]G1Affine
+			var b big.Int
+
+			for i := 0; i < nbSamples; i++ {
+				scalars[i].SetUint64(uint64(i+1)).Mul(&scalars[i], &mixer)
+				bases[i].ScalarMultiplication(&g1GenAff, big.NewInt(int64(i+2)))
+			}
+			// include the point at infinity and a zero scalar
+			bases[1] = G1Affine{}
+			scalars[2].SetZero()
+
+			result := BatchScalarMulG1(bases[:], scalars[:])
+			if len(result) != nbSamples {
+				return false
+			}
+
+			for i := 0; i < nbSamples; i++ {
+				var expected G1Affine
+				expected.ScalarMultiplication(&bases[i], scalars[i].ToBigIntRegular(&b))
+				if !result[i].Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestG1JacSelectPoint(t *testing.T) {
+	t.Parallel()
+
+	var table [15]G1Jac
+	table[0].Set(&g1Gen)
+	for i := 1; i < len(table); i++ {
+		table[i].Set(&table[i-1]).AddAssign(&g1Gen)
+	}
+
+	for i := range table {
+		var p G1Jac
+		p.selectPoint(table[:], i)
+		if p != table[i] {
+			t.Fatalf("selectPoint should return table[%d]", i)
+		}
+	}
+
+	// out of range indices yield the zero value
+	for _, i := range []int{-1, len(table), len(table) + 1} {
+		p := g1Gen
+		p.selectPoint(table[:], i)
+		if p != (G1Jac{}) {
+			t.Fatalf("selectPoint should return the zero value for index %d", i)
+		}
+	}
+}
+
+func TestG1AffineScalarMultiplicationCanonical(t *testing.T) {
+	t.Parallel()
+
+	var s fr.Element
+	s.SetRandom()
+	r := fr.Modulus()
+
+	var rMinusOne big.Int
+	rMinusOne.Sub(r, big.NewInt(1))
+
+	// in range
+	for _, k := range []*big.Int{big.NewInt(0), big.NewInt(1), s.ToBigIntRegular(new(big.Int)), &rMinusOne} {
+		var p, expected G1Affine
+		expected.ScalarMultiplication(&g1GenAff, k)
+		if _, err := p.ScalarMultiplicationCanonical(&g1GenAff, k); err != nil {
+			t.Fatal(err)
+		}
+		if !p.Equal(&expected) {
+			t.Fatal("ScalarMultiplicationCanonical should match ScalarMultiplication")
+		}
+	}
+
+	// r, above r and negative scalars are rejected, p is left unchanged
+	for _, k := range []*big.Int{r, new(big.Int).Add(r, big.NewInt(1)), new(big.Int).Lsh(r, 1), big.NewInt(-1)} {
+		p := g1GenAff
+		if _, err := p.ScalarMultiplicationCanonical(&g1GenAff, k); err == nil {
+			t.Fatal("ScalarMultiplicationCanonical should reject scalars out of [0, r)")
+		}
+		if !p.Equal(&g1GenAff) {
+			t.Fatal("p should be left unchanged on error")
+		}
+	}
+}
+
+func TestG1AffineScalarDiv(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	properties.Property("[BLS12-377] ScalarDiv(ScalarMultiplication(P, s), s) should equal P", prop.ForAll(
+		func(a, b fr.Element) bool {
+			if b.IsZero() {
+				return true
+			}
+			var _a, _b big.Int
+			a.ToBigIntRegular(&_a)
+			b.ToBigIntRegular(&_b)
+
+			var p, q, res G1Affine
+			p.ScalarMultiplication(&g1GenAff, &_a)
+			q.ScalarMultiplication(&p, &_b)
+			if _, err := res.ScalarDiv(&q, &_b); err != nil {
+				return false
+			}
+			return res.Equal(&p)
+		},
+		genScalar,
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// -1 and r-1 are inverted into -1
+	r := fr.Modulus()
+	var rMinusOne big.Int
+	rMinusOne.Sub(r, big.NewInt(1))
+	var neg G1Affine
+	neg.Neg(&g1GenAff)
+	for _, k := range []*big.Int{big.NewInt(-1), &rMinusOne} {
+		var p G1Affine
+		if _, err := p.ScalarDiv(&g1GenAff, k); err != nil {
+			t.Fatal(err)
+		}
+		if !p.Equal(&neg) {
+			t.Fatal("ScalarDiv by -1 should negate the point")
+		}
+	}
+
+	// multiples of r are not invertible, p is left unchanged
+	for _, k := range []*big.Int{big.NewInt(0), r, new(big.Int).Lsh(r, 1)} {
+		p := g1GenAff
+		if _, err := p.ScalarDiv(&g1GenAff, k); err == nil {
+			t.Fatal("ScalarDiv should reject scalars that are not invertible modulo r")
+		}
+		if !p.Equal(&g1GenAff) {
+			t.Fatal("p should be left unchanged on error")
+		}
+	}
+}
+
+func TestG1PrecomputedTwoBase(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = nbFuzzShort
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	properties.Property("[BLS12-377] PrecomputedTwoBase.Mul(a, b) should equal a ⋅ G + b ⋅ H", prop.ForAll(
+		func(a, b, c fr.Element) bool {
+			var _a, _b, _c big.Int
+			a.ToBigIntRegular(&_a)
+			b.ToBigIntRegular(&_b)
+			c.ToBigIntRegular(&_c)
+
+			var h, negG G1Affine
+			h.ScalarMultiplication(&g1GenAff, &_c)
+			negG.Neg(&g1GenAff)
+
+			check := func(g, h *G1Affine, a, b *big.Int) bool {
+				var aG, bH, expected G1Affine
+				aG.ScalarMultiplication(g, a)
+				bH.ScalarMultiplication(h, b)
+				expected.Add(&aG, &bH)
+				res := NewG1PrecomputedTwoBase(g, h).Mul(a, b)
+				return res.Equal(&expected)
+			}
+
+			var zero, negA big.Int
+			negA.Neg(&_a)
+
+			return check(&g1GenAff, &h, &_a, &_b) &&
+				check(&g1GenAff, &h, &zero, &_b) &&
+				check(&g1GenAff, &h, &_a, &zero) &&
+				check(&g1GenAff, &h, &negA, &_b) &&
+				// G = H and G + H = ∞
+				check(&g1GenAff, &g1GenAff, &_a, &_b) &&
+				check(&g1GenAff, &negG, &_a, &_b)
+		},
+		genScalar,
+		genScalar,
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestSumG1(t *testing.T) {
+	t.Parallel()
+
+	naive := func(points []G1Affine) G1Affine {
+		var acc G1Jac
+		acc.Set(&g1Infinity)
+		for i := range points {
+			acc.AddMixed(&points[i])
+		}
+		var res G1Affine
+		res.FromJacobian(&acc)
+		return res
+	}
+
+	for _, n := range []int{0, 1, 2, 17, 1 << 10, 1<<10 + 3} {
+		points := make([]G1Affine, n)
+		for i := range points {
+			points[i].ScalarMultiplication(&g1GenAff, big.NewInt(int64(i+1)))
+		}
+		if n > 2 {
+			// infinity, doubling and P + (-P)
+			points[0] = G1Affine{}
+			points[2] = points[1]
+			points[n-1].Neg(&points[n-2])
+		}
+
+		expected := naive(points)
+		for _, nbTasks := range []int{1, 3, 0} {
+			res := SumG1(points, nbTasks)
+			if !res.Equal(&expected) {
+				t.Fatalf("SumG1 of %d points with nbTasks=%d should match the naive sum", n, nbTasks)
+			}
+		}
+		if res := SumG1(points); !res.Equal(&expected) {
+			t.Fatalf("SumG1 of %d points should match the naive sum", n)
+		}
+	}
+
+	// Σ [i]G = [n(n+1)/2]G
+	const n = 100
+	points := make([]G1Affine, n)
+	for i := range points {
+		points[i].ScalarMultiplication(&g1GenAff, big.NewInt(int64(i+1)))
+	}
+	var expected G1Affine
+	expected.ScalarMultiplication(&g1GenAff, big.NewInt(n*(n+1)/2))
+	if res := SumG1(points); !res.Equal(&expected) {
+		t.Fatal("Σ [i]G should be [n(n+1)/2]G")
+	}
+}
+
+func TestFoldPointsG1(t *testing.T) {
+	t.Parallel()
+
+	// Horner: ((pₙ₋₁⋅r + pₙ₋₂)⋅r + ...)⋅r + p₀
+	horner := func(points []G1Affine, r fr.Element) G1Affine {
+		var acc G1Jac
+		acc.Set(&g1Infinity)
+		var br big.Int
+		r.ToBigIntRegular(&br)
+		for i := len(points) - 1; i >= 0; i-- {
+			acc.ScalarMultiplication(&acc, &br).AddMixed(&points[i])
+		}
+		var res G1Affine
+		res.FromJacobian(&acc)
+		return res
+	}
+
+	var r fr.Element
+	r.SetRandom()
+	for _, n := range []int{0, 1, 2, 17} {
+		points := make([]G1Affine, n)
+		for i := range points {
+			var s fr.Element
+			var bs big.Int
+			s.SetRandom()
+			points[i].ScalarMultiplication(&g1GenAff, s.ToBigIntRegular(&bs))
+		}
+		if n > 2 {
+			points[1] = G1Affine{}
+		}
+		expected := horner(points, r)
+		if res := FoldPointsG1(points, r); !res.Equal(&expected) {
+			t.Fatalf("FoldPointsG1 of %d points should match the Horner fold", n)
+		}
+	}
+
+	// r = 1 is the sum of the points
+	points := make([]G1Affine, 5)
+	for i := range points {
+		points[i].ScalarMultiplication(&g1GenAff, big.NewInt(int64(i+1)))
+	}
+	var one fr.Element
+	one.SetOne()
+	expected := SumG1(points)
+	if res := FoldPointsG1(points, one); !res.Equal(&expected) {
+		t.Fatal("FoldPointsG1 with r = 1 should be the sum of the points")
+	}
+}
+
+func TestG1Accumulator(t *testing.T) {
+	t.Parallel()
+
+	const n = 20
+	points := make([]G1Affine, n)
+	scalars := make([]big.Int, n)
+	for i := range points {
+		var s fr.Element
+		s.SetRandom()
+		points[i].ScalarMultiplication(&g1GenAff, s.ToBigIntRegular(&scalars[i]))
+		s.SetRandom()
+		s.ToBigIntRegular(&scalars[i])
+	}
+	// infinity, doubling and P + (-P)
+	points[0] = G1Affine{}
+	points[2] = points[1]
+	points[n-1].Neg(&points[n-2])
+
+	// the zero value is the point at infinity
+	var acc G1Accumulator
+	if res := acc.Result(); !res.IsInfinity() {
+		t.Fatal("the zero value of G1Accumulator should be the point at infinity")
+	}
+
+	// Add matches the explicit Jacobian sum
+	var expectedJac G1Jac
+	expectedJac.Set(&g1Infinity)
+	for i := range points {
+		var p G1Jac
+		p.FromAffine(&points[i])
+		expectedJac.AddAssign(&p)
+		acc.Add(&points[i])
+	}
+	var expected G1Affine
+	expected.FromJacobian(&expectedJac)
+	if res := acc.Result(); !res.Equal(&expected) {
+		t.Fatal("G1Accumulator.Add should match the Jacobian sum")
+	}
+
+	// AddScaled matches the explicit Jacobian scalar multiplications, on top of the running sum
+	for i := range points {
+		var p G1Jac
+		p.FromAffine(&points[i])
+		p.ScalarMultiplication(&p, &scalars[i])
+		expectedJac.AddAssign(&p)
+		acc.AddScaled(&points[i], &scalars[i])
+	}
+	expected.FromJacobian(&expectedJac)
+	if res := acc.Result(); !res.Equal(&expected) {
+		t.Fatal("G1Accumulator.AddScaled should match the Jacobian scalar multiplications")
+	}
+
+	// Result doesn't consume the running sum
+	if res := acc.Result(); !res.Equal(&expected) {
+		t.Fatal("G1Accumulator.Result should not modify the running sum")
+	}
+
+	// Reset
+	acc.Reset().Add(&g1GenAff)
+	if res := acc.Result(); !res.Equal(&g1GenAff) {
+		t.Fatal("G1Accumulator.Reset should set the running sum to the point at infinity")
+	}
+}
+
 // ------------------------------------------------------------
 // benches
 
+func BenchmarkG1AffineBatchScalarMul(b *testing.B) {
+	const nbSamples = 1 << 10
+	scalars := make([]fr.Element, nbSamples)
+	bases := make([]G1Affine, nbSamples)
+	for i := 0; i < nbSamples; i++ {
+		scalars[i].SetRandom()
+		bases[i].ScalarMultiplication(&g1GenAff, big.NewInt(int64(i+1)))
+	}
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		_ = BatchScalarMulG1(bases, scalars)
+	}
+}
+
+func BenchmarkSumG1(b *testing.B) {
+	const maxSize = 1 << 20
+	points := make([]G1Affine, maxSize)
+	points[0] = g1GenAff
+	for i := 1; i < 1<<10; i++ {
+		points[i].Add(&points[i-1], &g1GenAff)
+	}
+	for i := 1 << 10; i < maxSize; i++ {
+		points[i] = points[i%(1<<10)]
+	}
+
+	for size := 1 << 8; size <= maxSize; size <<= 4 {
+		b.Run(fmt.Sprintf("%d points", size), func(b *testing.B) {
+			for j := 0; j < b.N; j++ {
+				_ = SumG1(points[:size])
+			}
+		})
+	}
+}
+
+func BenchmarkG1PrecomputedTwoBase(b *testing.B) {
+	var _a, _b, _c big.Int
+	var a, c fr.Element
+	a.SetRandom()
+	a.ToBigIntRegular(&_a)
+	a.SetRandom()
+	a.ToBigIntRegular(&_b)
+	c.SetRandom()
+	c.ToBigIntRegular(&_c)
+
+	var h G1Affine
+	h.ScalarMultiplication(&g1GenAff, &_c)
+
+	b.Run("two scalar multiplications", func(b *testing.B) {
+		var aG, bH G1Affine
+		for j := 0; j < b.N; j++ {
+			aG.ScalarMultiplication(&g1GenAff, &_a)
+			bH.ScalarMultiplication(&h, &_b)
+			aG.Add(&aG, &bH)
+		}
+	})
+
+	b.Run("precomputed two base", func(b *testing.B) {
+		t := NewG1PrecomputedTwoBase(&g1GenAff, &h)
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			_ = t.Mul(&_a, &_b)
+		}
+	})
+}
+
+func BenchmarkBatchJacobianToAffineG1(b *testing.B) {
+	const nbPoints = 10000000
+	points := make([]G1Jac, nbPoints)
+	points[0].Set(&g1Gen)
+	for i := 1; i < nbPoints; i++ {
+		points[i].Set(&points[i-1]).AddMixed(&g1GenAff)
+	}
+
+	b.Run("single pass", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = BatchJacobianToAffineG1(points)
+		}
+	})
+
+	b.Run("chunked", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = BatchJacobianToAffineG1Chunked(points, 0)
+		}
+	})
+}
+
 func BenchmarkG1JacIsInSubGroup(b *testing.B) {
 	var a G1Jac
 	a.Set(&g1Gen)
@@ -553,6 +1478,86 @@ func BenchmarkG1JacScalarMultiplication(b *testing.B) {
 
 }
 
+// BenchmarkG1JacScalarMultiplicationRandom compares the windowed double and add with the GLV
+// scalar multiplication on random scalars.
+//
+// GLV splits the scalar in two halves, roughly halving the number of doublings: it is expected
+// to be about 1.5 to 2 times faster than the windowed method.
+func BenchmarkG1JacScalarMultiplicationRandom(b *testing.B) {
+	const nbScalars = 64
+	var scalars [nbScalars]big.Int
+	for i := 0; i < nbScalars; i++ {
+		var s fr.Element
+		s.SetRandom()
+		s.ToBigIntRegular(&scalars[i])
+	}
+
+	var res G1Jac
+
+	b.Run("windowed", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			res.mulWindowed(&g1Gen, &scalars[j%nbScalars])
+		}
+	})
+
+	b.Run("GLV", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			res.mulGLV(&g1Gen, &scalars[j%nbScalars])
+		}
+	})
+}
+
+// BenchmarkG1JacScalarMultiplicationSmall benchmarks the GLV scalar multiplication on 64-bit scalars.
+func BenchmarkG1JacScalarMultiplicationSmall(b *testing.B) {
+	const nbScalars = 64
+	var scalars [nbScalars]big.Int
+	for i := 0; i < nbScalars; i++ {
+		var s fr.Element
+		s.SetRandom()
+		scalars[i].SetUint64(s[0])
+	}
+
+	var res G1Jac
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		res.mulGLV(&g1Gen, &scalars[j%nbScalars])
+	}
+}
+
+func BenchmarkG1AffineScalarMulChain(b *testing.B) {
+	const nbSteps = 16
+	var scalars [nbSteps]big.Int
+	for i := 0; i < nbSteps; i++ {
+		var s fr.Element
+		s.SetRandom()
+		s.ToBigIntRegular(&scalars[i])
+	}
+
+	var res G1Affine
+	b.Run("affine at each step", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			res = g1GenAff
+			for i := 0; i < nbSteps; i++ {
+				res.ScalarMultiplication(&res, &scalars[i])
+			}
+		}
+	})
+
+	b.Run("jacobian chain", func(b *testing.B) {
+		scalarsPtr := make([]*big.Int, nbSteps)
+		for i := 0; i < nbSteps; i++ {
+			scalarsPtr[i] = &scalars[i]
+		}
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			res.ScalarMulChain(&g1GenAff, scalarsPtr...)
+		}
+	})
+}
+
 func BenchmarkG1AffineCofactorClearing(b *testing.B) {
 	var a G1Jac
 	a.Set(&g1Gen)
@@ -17,10 +17,14 @@
 package bls12377
 
 import (
+	"crypto/subtle"
+	"errors"
 	"math/big"
 	"runtime"
+	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/internal/fptower"
 	"github.com/consensys/gnark-crypto/internal/parallel"
@@ -55,7 +59,25 @@ func (p *G2Affine) Set(a *G2Affine) *G2Affine {
 	return p
 }
 
+// SetCoordinates sets p to (x, y), with x = x0 + x1⋅u and y = y0 + y1⋅u in 𝔽p², and returns p
+//
+// If checkOnCurve is set, it returns an error (and leaves p unchanged) if (x, y) is not on the curve.
+// Subgroup membership is never checked, see IsInSubGroup.
+func (p *G2Affine) SetCoordinates(x0, x1, y0, y1 fp.Element, checkOnCurve bool) (*G2Affine, error) {
+	var q G2Affine
+	q.X.A0, q.X.A1 = x0, x1
+	q.Y.A0, q.Y.A1 = y0, y1
+	if checkOnCurve && !q.IsOnCurve() {
+		return p, errors.New("invalid point: not on curve")
+	}
+	return p.Set(&q), nil
+}
+
 // ScalarMultiplication computes and returns p = a ⋅ s
+//
+// The result is converted back to affine coordinates, which costs a field inversion.
+// When chaining scalar multiplications, prefer ScalarMulChain or work with
+// G2Jac.ScalarMultiplicationAffine and convert once at the end.
 func (p *G2Affine) ScalarMultiplication(a *G2Affine, s *big.Int) *G2Affine {
 	var _p G2Jac
 	_p.FromAffine(a)
@@ -64,6 +86,51 @@ func (p *G2Affine) ScalarMultiplication(a *G2Affine, s *big.Int) *G2Affine {
 	return p
 }
 
+// ScalarMultiplicationCanonical computes and returns p = a ⋅ s like ScalarMultiplication,
+// but returns an error (and leaves p unchanged) if s is not in the canonical range [0, r)
+// instead of silently reducing it.
+func (p *G2Affine) ScalarMultiplicationCanonical(a *G2Affine, s *big.Int) (*G2Affine, error) {
+	if s.Sign() < 0 || s.Cmp(fr.Modulus()) >= 0 {
+		return p, errors.New("invalid scalar: not in [0, r)")
+	}
+	return p.ScalarMultiplication(a, s), nil
+}
+
+// ScalarDiv computes and returns p = a ⋅ s⁻¹, where s⁻¹ is the inverse of s modulo r
+//
+// It returns an error (and leaves p unchanged) if s is not invertible modulo r, i.e. if s ≡ 0 mod r.
+// Like ScalarMultiplication, it assumes a is in the prime-order subgroup.
+func (p *G2Affine) ScalarDiv(a *G2Affine, s *big.Int) (*G2Affine, error) {
+	var sInv big.Int
+	if sInv.ModInverse(s, fr.Modulus()) == nil {
+		return p, errors.New("invalid scalar: not invertible modulo r")
+	}
+	return p.ScalarMultiplication(a, &sInv), nil
+}
+
+// ScalarMulChain computes and returns p = a ⋅ s₀ ⋅ s₁ ⋯ sₙ₋₁
+//
+// Intermediate results are kept in Jacobian coordinates and a single conversion
+// to affine is performed at the end, whereas chaining calls to ScalarMultiplication
+// costs one field inversion per step.
+func (p *G2Affine) ScalarMulChain(a *G2Affine, scalars ...*big.Int) *G2Affine {
+	var _p G2Jac
+	_p.FromAffine(a)
+	for i := 0; i < len(scalars); i++ {
+		_p.mulGLV(&_p, scalars[i])
+	}
+	p.FromJacobian(&_p)
+	return p
+}
+
+// ScalarMultiplicationAffine computes and returns p = a ⋅ s
+// Takes an affine point and returns a Jacobian point (useful for KZG)
+func (p *G2Jac) ScalarMultiplicationAffine(a *G2Affine, s *big.Int) *G2Jac {
+	p.FromAffine(a)
+	p.mulGLV(p, s)
+	return p
+}
+
 // Add adds two point in affine coordinates.
 // This should rarely be used as it is very inefficient compared to Jacobian
 func (p *G2Affine) Add(a, b *G2Affine) *G2Affine {
@@ -134,9 +201,17 @@ func (p *G2Affine) IsInfinity() bool {
 
 // IsOnCurve returns true if p in on the curve
 func (p *G2Affine) IsOnCurve() bool {
-	var point G2Jac
-	point.FromAffine(p)
-	return point.IsOnCurve() // call this function to handle infinity point
+	if p.IsInfinity() {
+		var point G2Jac
+		point.FromAffine(p)
+		return point.IsOnCurve() // call this function to handle infinity point
+	}
+	// y² == x³ + b, directly in affine coordinates
+	var left, right fptower.E2
+	left.Square(&p.Y)
+	right.Square(&p.X).Mul(&right, &p.X)
+	right.Add(&right, &bTwistCurveCoeff)
+	return left.Equal(&right)
 }
 
 // IsInSubGroup returns true if p is in the correct subgroup, false otherwise
@@ -146,6 +221,38 @@ func (p *G2Affine) IsInSubGroup() bool {
 	return _p.IsInSubGroup()
 }
 
+// IsSmallOrder returns true if the order of p is at most bound (the point at infinity has order 1).
+//
+// It adds p to itself up to bound times, so bound is meant to be small, e.g. the largest
+// small prime factor of the cofactor. Together with IsInSubGroup, it helps rejecting the
+// points used in small-subgroup confinement attacks.
+func (p *G2Affine) IsSmallOrder(bound int) bool {
+	if p.IsInfinity() {
+		return true
+	}
+	var q G2Jac
+	q.FromAffine(p)
+	for k := 2; k <= bound; k++ {
+		q.AddMixed(p)
+		if q.Z.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// MulByCofactor computes and returns p = h ⋅ a, where h is the cofactor of G2 (see CofactorG2)
+//
+// Unlike ClearCofactor, which may use a cheaper multiple of h, it computes the literal h ⋅ a,
+// without reducing h modulo r, so that the result is exact for points out of the r-torsion too.
+func (p *G2Affine) MulByCofactor(a *G2Affine) *G2Affine {
+	var _p G2Jac
+	_p.FromAffine(a)
+	_p.mulWindowed(&_p, &cofactorG2)
+	p.FromJacobian(&_p)
+	return p
+}
+
 // -------------------------------------------------------------------------------------------------
 // Jacobian
 
@@ -355,6 +462,18 @@ func (p *G2Jac) FromAffine(Q *G2Affine) *G2Jac {
 	return p
 }
 
+// XAffine returns the x-coordinate of p in affine coordinates, X/Z², without computing
+// the affine y-coordinate (see G2Affine.FromJacobian). It returns 0 if p is infinity.
+func (p *G2Jac) XAffine() fptower.E2 {
+	var x fptower.E2
+	if p.Z.IsZero() {
+		return x
+	}
+	x.Square(&p.Z).Inverse(&x)
+	x.Mul(&x, &p.X)
+	return x
+}
+
 // IsOnCurve returns true if p in on the curve
 func (p *G2Jac) IsOnCurve() bool {
 	var left, right, tmp fptower.E2
@@ -410,6 +529,29 @@ func (p *G2Jac) mulWindowed(a *G2Jac, s *big.Int) *G2Jac {
 
 }
 
+// selectPoint sets p to table[index] and returns p.
+//
+// Unlike table[index], it reads every entry of the table and selects the right one with masks,
+// so that its memory access pattern does not depend on index (constant-time table lookup).
+// If index is not in [0, len(table)), p is set to the zero value.
+//
+// mulGLV and mulWindowed index their tables with secret scalar bits and are not constant time;
+// selectPoint is the lookup a constant-time scalar multiplication must use instead.
+func (p *G2Jac) selectPoint(table []G2Jac, index int) *G2Jac {
+	var res G2Jac
+	for i := range table {
+		c := subtle.ConstantTimeEq(int32(i), int32(index))
+		res.X.A0.Select(c, &res.X.A0, &table[i].X.A0)
+		res.X.A1.Select(c, &res.X.A1, &table[i].X.A1)
+		res.Y.A0.Select(c, &res.Y.A0, &table[i].Y.A0)
+		res.Y.A1.Select(c, &res.Y.A1, &table[i].Y.A1)
+		res.Z.A0.Select(c, &res.Z.A0, &table[i].Z.A0)
+		res.Z.A1.Select(c, &res.Z.A1, &table[i].Z.A1)
+	}
+	p.Set(&res)
+	return p
+}
+
 // ψ(p) = u o π o u⁻¹ where u:E'→E iso from the twist to E
 func (p *G2Jac) psi(a *G2Jac) *G2Jac {
 	p.Set(a)
@@ -484,7 +626,13 @@ func (p *G2Jac) mulGLV(a *G2Jac, s *big.Int) *G2Jac {
 	// loop starts from len(k1)/2 or len(k1)/2+1 due to the bounds
 	for i := hiWordIndex; i >= 0; i-- {
 		mask := uint64(3) << 62
-		for j := 0; j < 32; j++ {
+		j := 0
+		if i == hiWordIndex {
+			// skip the leading zero windows, small scalars need fewer iterations
+			j = (64 - (maxBit - 64*hiWordIndex)) / 2
+			mask >>= 2 * j
+		}
+		for ; j < 32; j++ {
 			res.Double(&res).Double(&res)
 			b1 := (k1[i] & mask) >> (62 - 2*j)
 			b2 := (k2[i] & mask) >> (62 - 2*j)
@@ -537,6 +685,45 @@ func (p *G2Jac) ClearCofactor(a *G2Jac) *G2Jac {
 
 }
 
+// -------------------------------------------------------------------------------------------------
+// Accumulator
+
+// G2Accumulator sums points as they arrive (e.g. to aggregate signatures).
+//
+// The running sum is kept in Jacobian coordinates and is only converted to affine
+// coordinates, which costs a field inversion, when calling Result.
+// The zero value is the point at infinity and is ready to use.
+type G2Accumulator struct {
+	sum G2Jac
+}
+
+// Add adds a to the running sum and returns acc
+func (acc *G2Accumulator) Add(a *G2Affine) *G2Accumulator {
+	acc.sum.AddMixed(a)
+	return acc
+}
+
+// AddScaled adds a ⋅ s to the running sum and returns acc
+func (acc *G2Accumulator) AddScaled(a *G2Affine, s *big.Int) *G2Accumulator {
+	var tmp G2Jac
+	tmp.ScalarMultiplicationAffine(a, s)
+	acc.sum.AddAssign(&tmp)
+	return acc
+}
+
+// Result returns the running sum in affine coordinates
+func (acc *G2Accumulator) Result() G2Affine {
+	var res G2Affine
+	res.FromJacobian(&acc.sum)
+	return res
+}
+
+// Reset sets the running sum to the point at infinity and returns acc
+func (acc *G2Accumulator) Reset() *G2Accumulator {
+	acc.sum = G2Jac{}
+	return acc
+}
+
 // -------------------------------------------------------------------------------------------------
 // Jacobian extended
 
@@ -871,7 +1058,21 @@ func (p *g2Proj) FromAffine(Q *G2Affine) *g2Proj {
 // BatchScalarMultiplicationG2 multiplies the same base by all scalars
 // and return resulting points in affine coordinates
 // uses a simple windowed-NAF like exponentiation algorithm
-func BatchScalarMultiplicationG2(base *G2Affine, scalars []fr.Element) []G2Affine {
+//
+// nbTasks optionally caps the number of go routines used (default: runtime.NumCPU()).
+func BatchScalarMultiplicationG2(base *G2Affine, scalars []fr.Element, nbTasks ...int) []G2Affine {
+
+	// no need to build the window tables for less than 2 scalars
+	switch len(scalars) {
+	case 0:
+		return []G2Affine{}
+	case 1:
+		var s big.Int
+		scalars[0].ToBigInt(&s) // scalars are not in Montgomery form
+		res := make([]G2Affine, 1)
+		res[0].ScalarMultiplication(base, &s)
+		return res
+	}
 
 	// approximate cost in group ops is
 	// cost = 2^{c-1} + n(scalar.nbBits+nbChunks)
@@ -968,6 +1169,155 @@ func BatchScalarMultiplicationG2(base *G2Affine, scalars []fr.Element) []G2Affin
 			toReturn[i].FromJacobian(&p)
 
 		}
-	})
+	}, nbTasks...)
 	return toReturn
 }
+
+// SumG2 returns Σᵢ points[i] in affine coordinates.
+//
+// The points are accumulated in Jacobian coordinates, hence a single field inversion is performed
+// at the end; large slices are split between go routines. Points at infinity are handled.
+//
+// nbTasks optionally caps the number of go routines used (default: runtime.NumCPU()).
+func SumG2(points []G2Affine, nbTasks ...int) G2Affine {
+	// below this size, the go routines overhead is not worth it
+	const minParallelSize = 1 << 10
+	if len(points) < minParallelSize {
+		nbTasks = []int{1}
+	}
+
+	var sum G2Jac
+	var lock sync.Mutex
+	sum.Set(&g2Infinity)
+
+	parallel.Execute(len(points), func(start, end int) {
+		var partial G2Jac
+		partial.Set(&g2Infinity)
+		for i := start; i < end; i++ {
+			partial.AddMixed(&points[i])
+		}
+		lock.Lock()
+		sum.AddAssign(&partial)
+		lock.Unlock()
+	}, nbTasks...)
+
+	var res G2Affine
+	res.FromJacobian(&sum)
+	return res
+}
+
+// FoldPointsG2 returns Σᵢ rⁱ⋅points[i] in affine coordinates, i.e. the random linear
+// combination of the points used to fold commitments or proofs with a challenge r.
+//
+// The powers of r are computed and the sum is performed with a single multi-exponentiation.
+// It returns the point at infinity if points is empty.
+func FoldPointsG2(points []G2Affine, r fr.Element) G2Affine {
+	var res G2Affine
+	if len(points) == 0 {
+		return res
+	}
+
+	// powers = [1, r, r², ..., rⁿ⁻¹]
+	powers := make([]fr.Element, len(points))
+	powers[0].SetOne()
+	for i := 1; i < len(powers); i++ {
+		powers[i].Mul(&powers[i-1], &r)
+	}
+
+	// the sizes match and the config is valid, MultiExp can't fail
+	_, _ = res.MultiExp(points, powers, ecc.MultiExpConfig{ScalarsMont: true})
+	return res
+}
+
+// BatchScalarMulG2 multiplies each base by its own scalar,
+// i.e. it returns [scalars[i] ⋅ bases[i]] (the results are not summed, see MultiExp for that)
+// in affine coordinates.
+//
+// It panics if len(bases) != len(scalars).
+//
+// nbTasks optionally caps the number of go routines used (default: runtime.NumCPU()).
+func BatchScalarMulG2(bases []G2Affine, scalars []fr.Element, nbTasks ...int) []G2Affine {
+	if len(bases) != len(scalars) {
+		panic("len(bases) != len(scalars)")
+	}
+	if len(scalars) == 0 {
+		return []G2Affine{}
+	}
+	toReturn := make([]G2Affine, len(scalars))
+
+	parallel.Execute(len(scalars), func(start, end int) {
+		var s big.Int
+		var p G2Jac
+		for i := start; i < end; i++ {
+			scalars[i].ToBigIntRegular(&s)
+			p.ScalarMultiplicationAffine(&bases[i], &s)
+			toReturn[i].FromJacobian(&p)
+		}
+	}, nbTasks...)
+	return toReturn
+}
+
+// G2PrecomputedTwoBase holds a combined window table to compute a ⋅ G + b ⋅ H
+// for two fixed bases G and H (e.g. the generators of a Pedersen commitment).
+//
+// The table stores i ⋅ G + j ⋅ H for 0 ≤ i, j < 4 (omitting ∞), so that G, H and G+H
+// (and the other combinations of 2-bit windows) are added without any further doubling.
+type G2PrecomputedTwoBase struct {
+	table [15]G2Affine
+}
+
+// NewG2PrecomputedTwoBase precomputes the combined window table for the bases g and h.
+func NewG2PrecomputedTwoBase(g, h *G2Affine) *G2PrecomputedTwoBase {
+	var jac [16]G2Jac
+	jac[0].Set(&g2Infinity)
+	jac[1].FromAffine(g)
+	jac[4].FromAffine(h)
+	// jac[i + 4j] = i ⋅ G + j ⋅ H
+	for c := 2; c < 16; c++ {
+		if c == 4 {
+			continue
+		}
+		if c%4 == 0 {
+			jac[c].Set(&jac[c-4]).AddAssign(&jac[4])
+		} else {
+			jac[c].Set(&jac[c-1]).AddAssign(&jac[1])
+		}
+	}
+
+	t := new(G2PrecomputedTwoBase)
+	for c := 1; c < 16; c++ {
+		t.table[c-1].FromJacobian(&jac[c])
+	}
+	return t
+}
+
+// Mul returns a ⋅ G + b ⋅ H in affine coordinates.
+//
+// It uses the Straus-Shamir trick: a single pass over the 2-bit windows of a and b,
+// with two doublings and at most one addition from the table per window.
+// The scalars are reduced modulo r, so G and H are expected to be in the prime-order subgroup.
+func (t *G2PrecomputedTwoBase) Mul(a, b *big.Int) G2Affine {
+	var _a, _b big.Int
+	_a.Mod(a, fr.Modulus())
+	_b.Mod(b, fr.Modulus())
+
+	n := _a.BitLen()
+	if _b.BitLen() > n {
+		n = _b.BitLen()
+	}
+	n += n & 1
+
+	var res G2Jac
+	res.Set(&g2Infinity)
+	for i := n - 2; i >= 0; i -= 2 {
+		res.DoubleAssign().DoubleAssign()
+		c := (_a.Bit(i) | _a.Bit(i+1)<<1) | (_b.Bit(i)|_b.Bit(i+1)<<1)<<2
+		if c != 0 {
+			res.AddMixed(&t.table[c-1])
+		}
+	}
+
+	var p G2Affine
+	p.FromJacobian(&res)
+	return p
+}
@@ -84,6 +84,51 @@ func TestG2AffineEndomorphism(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestG2AffineCofactor(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	var order big.Int
+	order.Mul(CofactorG2(), ScalarFieldModulus())
+
+	properties.Property("[BLS12-377] [CofactorG2⋅r] should clear any point of the curve", prop.ForAll(
+		func(a fptower.E2) bool {
+			// find a point on the curve, not necessarily in the r-torsion
+			var p G2Affine
+			var one, rhs fptower.E2
+			one.SetOne()
+			p.X = a
+			for {
+				rhs.Square(&p.X).Mul(&rhs, &p.X).Add(&rhs, &bTwistCurveCoeff)
+				if rhs.Legendre() != -1 {
+					break
+				}
+				p.X.Add(&p.X, &one)
+			}
+			p.Y.Sqrt(&rhs)
+			if !p.IsOnCurve() {
+				return false
+			}
+
+			var res, cleared G2Jac
+			res.FromAffine(&p)
+			cleared.mulWindowed(&res, CofactorG2())
+			res.mulWindowed(&res, &order)
+			return res.Z.IsZero() && cleared.IsInSubGroup()
+		},
+		GenE2(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
 func TestG2AffineIsOnCurve(t *testing.T) {
 	t.Parallel()
 	parameters := gopter.DefaultTestParameters()
@@ -119,6 +164,23 @@ func TestG2AffineIsOnCurve(t *testing.T) {
 		GenE2(),
 	))
 
+	properties.Property("[BLS12-377] IsOnCurve in affine and Jacobian coordinates should agree", prop.ForAll(
+		func(a fptower.E2) bool {
+			var op1, op2, inf G2Affine
+			var jac G2Jac
+			op1.FromJacobian(&g2Gen)
+			op2.Set(&op1)
+			op2.Y.Mul(&op2.Y, &a)
+			ok := true
+			for _, p := range []*G2Affine{&op1, &op2, &inf} {
+				jac.FromAffine(p)
+				ok = ok && p.IsOnCurve() == jac.IsOnCurve()
+			}
+			return ok && inf.IsOnCurve()
+		},
+		GenE2(),
+	))
+
 	properties.Property("[BLS12-377] IsInSubGroup and MulBy subgroup order should be the same", prop.ForAll(
 		func(a fptower.E2) bool {
 			var op1, op2 G2Jac
@@ -154,6 +216,28 @@ func TestG2AffineConversions(t *testing.T) {
 		GenE2(),
 	))
 
+	properties.Property("[BLS12-377] XAffine should match the x-coordinate of FromJacobian", prop.ForAll(
+		func(a fptower.E2) bool {
+			g := fuzzG2Jac(&g2Gen, a)
+			var op1 G2Affine
+			op1.FromJacobian(&g)
+			x := g.XAffine()
+			return x.Equal(&op1.X) && x.Equal(&g2Gen.X)
+		},
+		GenE2(),
+	))
+
+	properties.Property("[BLS12-377] XAffine of infinity should be 0", prop.ForAll(
+		func(a fptower.E2) bool {
+			var inf G2Jac
+			inf.Set(&g2Infinity)
+			inf.X.Mul(&inf.X, &a)
+			x := inf.XAffine()
+			return x.IsZero()
+		},
+		GenE2(),
+	))
+
 	properties.Property("[BLS12-377] Affine representation should be independent of a Extended Jacobian representative", prop.ForAll(
 		func(a fptower.E2) bool {
 			var g g2JacExtended
@@ -402,6 +486,220 @@ func TestG2AffineOps(t *testing.T) {
 		genScalar,
 	))
 
+	properties.Property("[BLS12-377] GLV and Double and Add should output the same result on small scalars", prop.ForAll(
+		func(s fr.Element) bool {
+
+			var r, mask, small big.Int
+			var op1, op2 G2Jac
+			s.ToBigIntRegular(&r)
+			for _, nbBits := range []uint{0, 1, 2, 3, 8, 17, 63, 64, 65, 100} {
+				mask.Lsh(big.NewInt(1), nbBits).Sub(&mask, big.NewInt(1))
+				small.And(&r, &mask)
+				op1.mulWindowed(&g2Gen, &small)
+				op2.mulGLV(&g2Gen, &small)
+				if !op1.Equal(&op2) {
+					return false
+				}
+			}
+			return true
+
+		},
+		genScalar,
+	))
+
+	properties.Property("[BLS12-377] ScalarMulChain should output the same result as chained ScalarMultiplication", prop.ForAll(
+		func(s1, s2 fr.Element) bool {
+
+			var r1, r2 big.Int
+			var op1, op2 G2Affine
+			s1.ToBigIntRegular(&r1)
+			s2.ToBigIntRegular(&r2)
+			op1.ScalarMultiplication(&g2GenAff, &r1).
+				ScalarMultiplication(&op1, &r2)
+			op2.ScalarMulChain(&g2GenAff, &r1, &r2)
+			return op1.Equal(&op2)
+
+		},
+		genScalar,
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestG2AffineIsSmallOrder(t *testing.T) {
+	t.Parallel()
+
+	var infinity G2Affine
+	if !infinity.IsSmallOrder(1) {
+		t.Fatal("the point at infinity has order 1")
+	}
+
+	var g G2Affine
+	g.FromJacobian(&g2Gen)
+	if g.IsSmallOrder(1000) {
+		t.Fatal("the generator has order r")
+	}
+
+	// smallest prime factor of the cofactor, if it is small
+	q := 0
+	for _, c := range []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47} {
+		if new(big.Int).Mod(CofactorG2(), big.NewInt(int64(c))).Sign() == 0 {
+			q = c
+			break
+		}
+	}
+	if q == 0 {
+		t.Log("no small prime factor in the cofactor")
+		return
+	}
+
+	// m = h⋅r/qᵉ with q ∤ m: [m]p is in the q-torsion part of the group for any p of the curve,
+	// and multiplying it by q until the next multiple is 0 gives a point of order q.
+	// (The q-torsion part may not be cyclic, so [h⋅r/q]p can be 0 for all p.)
+	var m, bq, rem big.Int
+	bq.SetInt64(int64(q))
+	m.Mul(CofactorG2(), ScalarFieldModulus())
+	for {
+		var quo big.Int
+		quo.QuoRem(&m, &bq, &rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		m.Set(&quo)
+	}
+	var one, rhs fptower.E2
+	one.SetOne()
+	var p G2Affine
+	p.X.SetOne()
+	for {
+		rhs.Square(&p.X).Mul(&rhs, &p.X).Add(&rhs, &bTwistCurveCoeff)
+		if rhs.Legendre() == 1 {
+			p.Y.Sqrt(&rhs)
+			var _p, next G2Jac
+			_p.FromAffine(&p)
+			_p.mulWindowed(&_p, &m)
+			if !_p.Z.IsZero() {
+				for {
+					next.mulWindowed(&_p, &bq)
+					if next.Z.IsZero() {
+						break
+					}
+					_p.Set(&next)
+				}
+				p.FromJacobian(&_p)
+				break
+			}
+		}
+		p.X.Add(&p.X, &one)
+	}
+	var pr G2Jac
+	pr.FromAffine(&p)
+	pr.mulWindowed(&pr, ScalarFieldModulus())
+	if !p.IsOnCurve() || p.IsInfinity() || pr.Z.IsZero() {
+		t.Fatal("the constructed point should be on the curve, out of the r-torsion")
+	}
+	if !p.IsSmallOrder(q) {
+		t.Fatalf("the constructed point should have order %d", q)
+	}
+	if p.IsSmallOrder(q - 1) {
+		t.Fatalf("the constructed point should have order exactly %d", q)
+	}
+
+	// neither the small order point nor its sum with a point of the r-torsion are in the subgroup
+	if p.IsInSubGroup() {
+		t.Fatal("a point of small order should not be in the subgroup")
+	}
+	pr.Set(&g2Gen).AddMixed(&p)
+	if pr.IsInSubGroup() {
+		t.Fatal("the generator plus a point of small order should not be in the subgroup")
+	}
+}
+
+func TestG2AffineIsInSubGroupOrder3(t *testing.T) {
+	t.Parallel()
+
+	// the points (0, ±√b) have order 3, and are on the curve when b is a square
+	var p G2Affine
+	b := bTwistCurveCoeff
+	if b.Legendre() != 1 {
+		t.Skip("b is not a square")
+	}
+	p.Y.Sqrt(&b)
+	if !p.IsOnCurve() || !p.IsSmallOrder(3) {
+		t.Fatal("(0, √b) should be a point of order 3")
+	}
+	if p.IsInSubGroup() {
+		t.Fatal("(0, √b) should not be in the subgroup")
+	}
+	var q G2Jac
+	q.Set(&g2Gen).AddMixed(&p)
+	if q.IsInSubGroup() {
+		t.Fatal("the generator plus (0, √b) should not be in the subgroup")
+	}
+}
+
+func TestG2AffineMulByCofactor(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-377] MulByCofactor should map any point of the curve in the r-torsion", prop.ForAll(
+		func(a fptower.E2) bool {
+			// find a point on the curve, not necessarily in the r-torsion
+			var p G2Affine
+			var one, rhs fptower.E2
+			one.SetOne()
+			p.X = a
+			for {
+				rhs.Square(&p.X).Mul(&rhs, &p.X).Add(&rhs, &bTwistCurveCoeff)
+				if rhs.Legendre() != -1 {
+					break
+				}
+				p.X.Add(&p.X, &one)
+			}
+			p.Y.Sqrt(&rhs)
+
+			var res G2Affine
+			res.MulByCofactor(&p)
+			if !res.IsOnCurve() || !res.IsInSubGroup() {
+				return false
+			}
+
+			// ClearCofactor and MulByCofactor are both multiplications by a multiple of h
+			// (or an endomorphism) and commute
+			var cleared, left, right G2Affine
+			cleared.ClearCofactor(&p)
+			left.MulByCofactor(&cleared)
+			right.ClearCofactor(&res)
+			if !left.Equal(&right) {
+				return false
+			}
+			return true
+		},
+		GenE2(),
+	))
+
+	properties.Property("[BLS12-377] MulByCofactor should match the multiplication by h mod r in the r-torsion", prop.ForAll(
+		func(s fr.Element) bool {
+			var g, res, expected G2Affine
+			var sBig, h big.Int
+			s.ToBigIntRegular(&sBig)
+			g.ScalarMultiplication(&g2GenAff, &sBig)
+			res.MulByCofactor(&g)
+			h.Mod(CofactorG2(), fr.Modulus())
+			expected.ScalarMultiplication(&g, &h)
+			return res.Equal(&expected)
+		},
+		GenFr(),
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
@@ -489,12 +787,556 @@ func TestG2AffineBatchScalarMultiplication(t *testing.T) {
 		genScalar,
 	))
 
+	properties.Property("[BLS12-377] BatchScalarMultiplication and BatchScalarMul should not depend on nbTasks", prop.ForAll(
+		func(mixer fr.Element) bool {
+			var sampleScalars [nbSamples]fr.Element
+			bases := make([]G2Affine, nbSamples)
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+				bases[i-1].ScalarMultiplication(&g2GenAff, big.NewInt(int64(i)))
+			}
+
+			expected := BatchScalarMultiplicationG2(&g2GenAff, sampleScalars[:])
+			expectedMul := BatchScalarMulG2(bases, sampleScalars[:])
+			for _, nbTasks := range []int{1, 2, 3, nbSamples + 1} {
+				result := BatchScalarMultiplicationG2(&g2GenAff, sampleScalars[:], nbTasks)
+				resultMul := BatchScalarMulG2(bases, sampleScalars[:], nbTasks)
+				for i := 0; i < nbSamples; i++ {
+					if !result[i].Equal(&expected[i]) || !resultMul[i].Equal(&expectedMul[i]) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestG2AffineBatchScalarMultiplicationSmall(t *testing.T) {
+	t.Parallel()
+
+	// zero-length inputs
+	if res := BatchScalarMultiplicationG2(&g2GenAff, nil); res == nil || len(res) != 0 {
+		t.Fatal("BatchScalarMultiplication on no scalars should return an empty slice")
+	}
+	if res := BatchScalarMulG2(nil, nil); res == nil || len(res) != 0 {
+		t.Fatal("BatchScalarMul on no scalars should return an empty slice")
+	}
+
+	// single scalar, including zero
+	var scalars [3]fr.Element
+	scalars[0].SetRandom()
+	scalars[1].SetOne()
+	for i := range scalars {
+		var b big.Int
+		var expected G2Affine
+		expected.ScalarMultiplication(&g2GenAff, scalars[i].ToBigIntRegular(&b))
+
+		regular := scalars[i]
+		regular.FromMont()
+		res := BatchScalarMultiplicationG2(&g2GenAff, []fr.Element{regular})
+		if len(res) != 1 || !res[0].Equal(&expected) {
+			t.Fatal("BatchScalarMultiplication on a single scalar is incorrect")
+		}
+
+		res = BatchScalarMulG2([]G2Affine{g2GenAff}, scalars[i:i+1])
+		if len(res) != 1 || !res[0].Equal(&expected) {
+			t.Fatal("BatchScalarMul on a single scalar is incorrect")
+		}
+	}
+}
+
+func TestG2AffineBatchScalarMul(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = nbFuzzShort
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	const nbSamples = 10
+
+	properties.Property("[BLS12-377] BatchScalarMul should be consistent with individual scalar multiplications", prop.ForAll(
+		func(mixer fr.Element) bool {
+			var scalars [nbSamples]fr.Element
+			var bases [nbSamples]G2Affine
+			var b big.Int
+
+			for i := 0; i < nbSamples; i++ {
+				scalars[i].SetUint64(uint64(i+1)).Mul(&scalars[i], &mixer)
+				bases[i].ScalarMultiplication(&g2GenAff, big.NewInt(int64(i+2)))
+			}
+			// include the point at infinity and a zero scalar
+			bases[1] = G2Affine{}
+			scalars[2].SetZero()
+
+			result := BatchScalarMulG2(bases[:], scalars[:])
+			if len(result) != nbSamples {
+				return false
+			}
+
+			for i := 0; i < nbSamples; i++ {
+				var expected G2Affine
+				expected.ScalarMultiplication(&bases[i], scalars[i].ToBigIntRegular(&b))
+				if !result[i].Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestG2JacSelectPoint(t *testing.T) {
+	t.Parallel()
+
+	var table [15]G2Jac
+	table[0].Set(&g2Gen)
+	for i := 1; i < len(table); i++ {
+		table[i].Set(&table[i-1]).AddAssign(&g2Gen)
+	}
+
+	for i := range table {
+		var p G2Jac
+		p.selectPoint(table[:], i)
+		if p != table[i] {
+			t.Fatalf("selectPoint should return table[%d]", i)
+		}
+	}
+
+	// out of range indices yield the zero value
+	for _, i := range []int{-1, len(table), len(table) + 1} {
+		p := g2Gen
+		p.selectPoint(table[:], i)
+		if p != (G2Jac{}) {
+			t.Fatalf("selectPoint should return the zero value for index %d", i)
+		}
+	}
+}
+
+func TestG2AffineScalarMultiplicationCanonical(t *testing.T) {
+	t.Parallel()
+
+	var s fr.Element
+	s.SetRandom()
+	r := fr.Modulus()
+
+	var rMinusOne big.Int
+	rMinusOne.Sub(r, big.NewInt(1))
+
+	// in range
+	for _, k := range []*big.Int{big.NewInt(0), big.NewInt(1), s.ToBigIntRegular(new(big.Int)), &rMinusOne} {
+		var p, expected G2Affine
+		expected.ScalarMultiplication(&g2GenAff, k)
+		if _, err := p.ScalarMultiplicationCanonical(&g2GenAff, k); err != nil {
+			t.Fatal(err)
+		}
+		if !p.Equal(&expected) {
+			t.Fatal("ScalarMultiplicationCanonical should match ScalarMultiplication")
+		}
+	}
+
+	// r, above r and negative scalars are rejected, p is left unchanged
+	for _, k := range []*big.Int{r, new(big.Int).Add(r, big.NewInt(1)), new(big.Int).Lsh(r, 1), big.NewInt(-1)} {
+		p := g2GenAff
+		if _, err := p.ScalarMultiplicationCanonical(&g2GenAff, k); err == nil {
+			t.Fatal("ScalarMultiplicationCanonical should reject scalars out of [0, r)")
+		}
+		if !p.Equal(&g2GenAff) {
+			t.Fatal("p should be left unchanged on error")
+		}
+	}
+}
+
+func TestG2AffineScalarDiv(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	properties.Property("[BLS12-377] ScalarDiv(ScalarMultiplication(P, s), s) should equal P", prop.ForAll(
+		func(a, b fr.Element) bool {
+			if b.IsZero() {
+				return true
+			}
+			var _a, _b big.Int
+			a.ToBigIntRegular(&_a)
+			b.ToBigIntRegular(&_b)
+
+			var p, q, res G2Affine
+			p.ScalarMultiplication(&g2GenAff, &_a)
+			q.ScalarMultiplication(&p, &_b)
+			if _, err := res.ScalarDiv(&q, &_b); err != nil {
+				return false
+			}
+			return res.Equal(&p)
+		},
+		genScalar,
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// -1 and r-1 are inverted into -1
+	r := fr.Modulus()
+	var rMinusOne big.Int
+	rMinusOne.Sub(r, big.NewInt(1))
+	var neg G2Affine
+	neg.Neg(&g2GenAff)
+	for _, k := range []*big.Int{big.NewInt(-1), &rMinusOne} {
+		var p G2Affine
+		if _, err := p.ScalarDiv(&g2GenAff, k); err != nil {
+			t.Fatal(err)
+		}
+		if !p.Equal(&neg) {
+			t.Fatal("ScalarDiv by -1 should negate the point")
+		}
+	}
+
+	// multiples of r are not invertible, p is left unchanged
+	for _, k := range []*big.Int{big.NewInt(0), r, new(big.Int).Lsh(r, 1)} {
+		p := g2GenAff
+		if _, err := p.ScalarDiv(&g2GenAff, k); err == nil {
+			t.Fatal("ScalarDiv should reject scalars that are not invertible modulo r")
+		}
+		if !p.Equal(&g2GenAff) {
+			t.Fatal("p should be left unchanged on error")
+		}
+	}
+}
+
+func TestG2AffineSetCoordinates(t *testing.T) {
+	t.Parallel()
+
+	// the generator, from its coordinates
+	x, y := g2GenAff.X, g2GenAff.Y
+	for _, checkOnCurve := range []bool{false, true} {
+		var p G2Affine
+		if _, err := p.SetCoordinates(x.A0, x.A1, y.A0, y.A1, checkOnCurve); err != nil {
+			t.Fatal(err)
+		}
+		if !p.Equal(&g2GenAff) {
+			t.Fatal("SetCoordinates should build the generator")
+		}
+		if !p.IsOnCurve() || !p.IsInSubGroup() {
+			t.Fatal("the generator should be on the curve and in the subgroup")
+		}
+	}
+
+	// a point that is not on the curve is only accepted without the check
+	y0 := y.A0
+	y0.Double(&y0)
+	p := g2GenAff
+	if _, err := p.SetCoordinates(x.A0, x.A1, y0, y.A1, true); err == nil {
+		t.Fatal("SetCoordinates should reject points that are not on the curve")
+	}
+	if !p.Equal(&g2GenAff) {
+		t.Fatal("p should be left unchanged on error")
+	}
+	if _, err := p.SetCoordinates(x.A0, x.A1, y0, y.A1, false); err != nil {
+		t.Fatal(err)
+	}
+	if p.IsOnCurve() {
+		t.Fatal("(x, 2y) should not be on the curve")
+	}
+}
+
+func TestG2PrecomputedTwoBase(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = nbFuzzShort
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	properties.Property("[BLS12-377] PrecomputedTwoBase.Mul(a, b) should equal a ⋅ G + b ⋅ H", prop.ForAll(
+		func(a, b, c fr.Element) bool {
+			var _a, _b, _c big.Int
+			a.ToBigIntRegular(&_a)
+			b.ToBigIntRegular(&_b)
+			c.ToBigIntRegular(&_c)
+
+			var h, negG G2Affine
+			h.ScalarMultiplication(&g2GenAff, &_c)
+			negG.Neg(&g2GenAff)
+
+			check := func(g, h *G2Affine, a, b *big.Int) bool {
+				var aG, bH, expected G2Affine
+				aG.ScalarMultiplication(g, a)
+				bH.ScalarMultiplication(h, b)
+				expected.Add(&aG, &bH)
+				res := NewG2PrecomputedTwoBase(g, h).Mul(a, b)
+				return res.Equal(&expected)
+			}
+
+			var zero, negA big.Int
+			negA.Neg(&_a)
+
+			return check(&g2GenAff, &h, &_a, &_b) &&
+				check(&g2GenAff, &h, &zero, &_b) &&
+				check(&g2GenAff, &h, &_a, &zero) &&
+				check(&g2GenAff, &h, &negA, &_b) &&
+				// G = H and G + H = ∞
+				check(&g2GenAff, &g2GenAff, &_a, &_b) &&
+				check(&g2GenAff, &negG, &_a, &_b)
+		},
+		genScalar,
+		genScalar,
+		genScalar,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestSumG2(t *testing.T) {
+	t.Parallel()
+
+	naive := func(points []G2Affine) G2Affine {
+		var acc G2Jac
+		acc.Set(&g2Infinity)
+		for i := range points {
+			acc.AddMixed(&points[i])
+		}
+		var res G2Affine
+		res.FromJacobian(&acc)
+		return res
+	}
+
+	for _, n := range []int{0, 1, 2, 17, 1 << 10, 1<<10 + 3} {
+		points := make([]G2Affine, n)
+		for i := range points {
+			points[i].ScalarMultiplication(&g2GenAff, big.NewInt(int64(i+1)))
+		}
+		if n > 2 {
+			// infinity, doubling and P + (-P)
+			points[0] = G2Affine{}
+			points[2] = points[1]
+			points[n-1].Neg(&points[n-2])
+		}
+
+		expected := naive(points)
+		for _, nbTasks := range []int{1, 3, 0} {
+			res := SumG2(points, nbTasks)
+			if !res.Equal(&expected) {
+				t.Fatalf("SumG2 of %d points with nbTasks=%d should match the naive sum", n, nbTasks)
+			}
+		}
+		if res := SumG2(points); !res.Equal(&expected) {
+			t.Fatalf("SumG2 of %d points should match the naive sum", n)
+		}
+	}
+
+	// Σ [i]G = [n(n+1)/2]G
+	const n = 100
+	points := make([]G2Affine, n)
+	for i := range points {
+		points[i].ScalarMultiplication(&g2GenAff, big.NewInt(int64(i+1)))
+	}
+	var expected G2Affine
+	expected.ScalarMultiplication(&g2GenAff, big.NewInt(n*(n+1)/2))
+	if res := SumG2(points); !res.Equal(&expected) {
+		t.Fatal("Σ [i]G should be [n(n+1)/2]G")
+	}
+}
+
+func TestFoldPointsG2(t *testing.T) {
+	t.Parallel()
+
+	// Horner: ((pₙ₋₁⋅r + pₙ₋₂)⋅r + ...)⋅r + p₀
+	horner := func(points []G2Affine, r fr.Element) G2Affine {
+		var acc G2Jac
+		acc.Set(&g2Infinity)
+		var br big.Int
+		r.ToBigIntRegular(&br)
+		for i := len(points) - 1; i >= 0; i-- {
+			acc.ScalarMultiplication(&acc, &br).AddMixed(&points[i])
+		}
+		var res G2Affine
+		res.FromJacobian(&acc)
+		return res
+	}
+
+	var r fr.Element
+	r.SetRandom()
+	for _, n := range []int{0, 1, 2, 17} {
+		points := make([]G2Affine, n)
+		for i := range points {
+			var s fr.Element
+			var bs big.Int
+			s.SetRandom()
+			points[i].ScalarMultiplication(&g2GenAff, s.ToBigIntRegular(&bs))
+		}
+		if n > 2 {
+			points[1] = G2Affine{}
+		}
+		expected := horner(points, r)
+		if res := FoldPointsG2(points, r); !res.Equal(&expected) {
+			t.Fatalf("FoldPointsG2 of %d points should match the Horner fold", n)
+		}
+	}
+
+	// r = 1 is the sum of the points
+	points := make([]G2Affine, 5)
+	for i := range points {
+		points[i].ScalarMultiplication(&g2GenAff, big.NewInt(int64(i+1)))
+	}
+	var one fr.Element
+	one.SetOne()
+	expected := SumG2(points)
+	if res := FoldPointsG2(points, one); !res.Equal(&expected) {
+		t.Fatal("FoldPointsG2 with r = 1 should be the sum of the points")
+	}
+}
+
+func TestG2Accumulator(t *testing.T) {
+	t.Parallel()
+
+	const n = 20
+	points := make([]G2Affine, n)
+	scalars := make([]big.Int, n)
+	for i := range points {
+		var s fr.Element
+		s.SetRandom()
+		points[i].ScalarMultiplication(&g2GenAff, s.ToBigIntRegular(&scalars[i]))
+		s.SetRandom()
+		s.ToBigIntRegular(&scalars[i])
+	}
+	// infinity, doubling and P + (-P)
+	points[0] = G2Affine{}
+	points[2] = points[1]
+	points[n-1].Neg(&points[n-2])
+
+	// the zero value is the point at infinity
+	var acc G2Accumulator
+	if res := acc.Result(); !res.IsInfinity() {
+		t.Fatal("the zero value of G2Accumulator should be the point at infinity")
+	}
+
+	// Add matches the explicit Jacobian sum
+	var expectedJac G2Jac
+	expectedJac.Set(&g2Infinity)
+	for i := range points {
+		var p G2Jac
+		p.FromAffine(&points[i])
+		expectedJac.AddAssign(&p)
+		acc.Add(&points[i])
+	}
+	var expected G2Affine
+	expected.FromJacobian(&expectedJac)
+	if res := acc.Result(); !res.Equal(&expected) {
+		t.Fatal("G2Accumulator.Add should match the Jacobian sum")
+	}
+
+	// AddScaled matches the explicit Jacobian scalar multiplications, on top of the running sum
+	for i := range points {
+		var p G2Jac
+		p.FromAffine(&points[i])
+		p.ScalarMultiplication(&p, &scalars[i])
+		expectedJac.AddAssign(&p)
+		acc.AddScaled(&points[i], &scalars[i])
+	}
+	expected.FromJacobian(&expectedJac)
+	if res := acc.Result(); !res.Equal(&expected) {
+		t.Fatal("G2Accumulator.AddScaled should match the Jacobian scalar multiplications")
+	}
+
+	// Result doesn't consume the running sum
+	if res := acc.Result(); !res.Equal(&expected) {
+		t.Fatal("G2Accumulator.Result should not modify the running sum")
+	}
+
+	// Reset
+	acc.Reset().Add(&g2GenAff)
+	if res := acc.Result(); !res.Equal(&g2GenAff) {
+		t.Fatal("G2Accumulator.Reset should set the running sum to the point at infinity")
+	}
+}
+
 // ------------------------------------------------------------
 // benches
 
+func BenchmarkG2AffineBatchScalarMul(b *testing.B) {
+	const nbSamples = 1 << 10
+	scalars := make([]fr.Element, nbSamples)
+	bases := make([]G2Affine, nbSamples)
+	for i := 0; i < nbSamples; i++ {
+		scalars[i].SetRandom()
+		bases[i].ScalarMultiplication(&g2GenAff, big.NewInt(int64(i+1)))
+	}
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		_ = BatchScalarMulG2(bases, scalars)
+	}
+}
+
+func BenchmarkSumG2(b *testing.B) {
+	const maxSize = 1 << 20
+	points := make([]G2Affine, maxSize)
+	points[0] = g2GenAff
+	for i := 1; i < 1<<10; i++ {
+		points[i].Add(&points[i-1], &g2GenAff)
+	}
+	for i := 1 << 10; i < maxSize; i++ {
+		points[i] = points[i%(1<<10)]
+	}
+
+	for size := 1 << 8; size <= maxSize; size <<= 4 {
+		b.Run(fmt.Sprintf("%d points", size), func(b *testing.B) {
+			for j := 0; j < b.N; j++ {
+				_ = SumG2(points[:size])
+			}
+		})
+	}
+}
+
+func BenchmarkG2PrecomputedTwoBase(b *testing.B) {
+	var _a, _b, _c big.Int
+	var a, c fr.Element
+	a.SetRandom()
+	a.ToBigIntRegular(&_a)
+	a.SetRandom()
+	a.ToBigIntRegular(&_b)
+	c.SetRandom()
+	c.ToBigIntRegular(&_c)
+
+	var h G2Affine
+	h.ScalarMultiplication(&g2GenAff, &_c)
+
+	b.Run("two scalar multiplications", func(b *testing.B) {
+		var aG, bH G2Affine
+		for j := 0; j < b.N; j++ {
+			aG.ScalarMultiplication(&g2GenAff, &_a)
+			bH.ScalarMultiplication(&h, &_b)
+			aG.Add(&aG, &bH)
+		}
+	})
+
+	b.Run("precomputed two base", func(b *testing.B) {
+		t := NewG2PrecomputedTwoBase(&g2GenAff, &h)
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			_ = t.Mul(&_a, &_b)
+		}
+	})
+}
+
 func BenchmarkG2JacIsInSubGroup(b *testing.B) {
 	var a G2Jac
 	a.Set(&g2Gen)
@@ -559,6 +1401,86 @@ func BenchmarkG2JacScalarMultiplication(b *testing.B) {
 
 }
 
+// BenchmarkG2JacScalarMultiplicationRandom compares the windowed double and add with the GLV
+// scalar multiplication on random scalars.
+//
+// GLV splits the scalar in two halves, roughly halving the number of doublings: it is expected
+// to be about 1.5 to 2 times faster than the windowed method.
+func BenchmarkG2JacScalarMultiplicationRandom(b *testing.B) {
+	const nbScalars = 64
+	var scalars [nbScalars]big.Int
+	for i := 0; i < nbScalars; i++ {
+		var s fr.Element
+		s.SetRandom()
+		s.ToBigIntRegular(&scalars[i])
+	}
+
+	var res G2Jac
+
+	b.Run("windowed", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			res.mulWindowed(&g2Gen, &scalars[j%nbScalars])
+		}
+	})
+
+	b.Run("GLV", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			res.mulGLV(&g2Gen, &scalars[j%nbScalars])
+		}
+	})
+}
+
+// BenchmarkG2JacScalarMultiplicationSmall benchmarks the GLV scalar multiplication on 64-bit scalars.
+func BenchmarkG2JacScalarMultiplicationSmall(b *testing.B) {
+	const nbScalars = 64
+	var scalars [nbScalars]big.Int
+	for i := 0; i < nbScalars; i++ {
+		var s fr.Element
+		s.SetRandom()
+		scalars[i].SetUint64(s[0])
+	}
+
+	var res G2Jac
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		res.mulGLV(&g2Gen, &scalars[j%nbScalars])
+	}
+}
+
+func BenchmarkG2AffineScalarMulChain(b *testing.B) {
+	const nbSteps = 16
+	var scalars [nbSteps]big.Int
+	for i := 0; i < nbSteps; i++ {
+		var s fr.Element
+		s.SetRandom()
+		s.ToBigIntRegular(&scalars[i])
+	}
+
+	var res G2Affine
+	b.Run("affine at each step", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			res = g2GenAff
+			for i := 0; i < nbSteps; i++ {
+				res.ScalarMultiplication(&res, &scalars[i])
+			}
+		}
+	})
+
+	b.Run("jacobian chain", func(b *testing.B) {
+		scalarsPtr := make([]*big.Int, nbSteps)
+		for i := 0; i < nbSteps; i++ {
+			scalarsPtr[i] = &scalars[i]
+		}
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			res.ScalarMulChain(&g2GenAff, scalarsPtr...)
+		}
+	})
+}
+
 func BenchmarkG2AffineCofactorClearing(b *testing.B) {
 	var a G2Jac
 	a.Set(&g2Gen)
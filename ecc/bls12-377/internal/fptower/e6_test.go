@@ -17,8 +17,10 @@
 package fptower
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/prop"
 )
@@ -289,6 +291,28 @@ func TestE6Ops(t *testing.T) {
 
 }
 
+func TestE6ExpNegative(t *testing.T) {
+	t.Parallel()
+
+	for _, k := range []*big.Int{
+		big.NewInt(1),
+		big.NewInt(2),
+		big.NewInt(12345),
+		new(big.Int).Lsh(big.NewInt(1), 200),
+		fp.Modulus(),
+	} {
+		var x, expected, res E6
+		x.SetRandom()
+
+		// x⁻ᵏ == (xᵏ)⁻¹
+		expected.Exp(x, k).Inverse(&expected)
+		res.Exp(x, new(big.Int).Neg(k))
+		if !res.Equal(&expected) {
+			t.Fatalf("Exp(x, -%s) should be Inverse(Exp(x, %s))", k.String(), k.String())
+		}
+	}
+}
+
 // ------------------------------------------------------------
 // benches
 
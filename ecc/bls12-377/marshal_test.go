@@ -18,6 +18,7 @@ package bls12377
 
 import (
 	"bytes"
+	"encoding/hex"
 	"io"
 	"math/big"
 	"math/rand"
@@ -249,6 +250,109 @@ func TestG1AffineSerialization(t *testing.T) {
 		}
 	}
 
+	// test BothBytes on infinity
+	{
+		var p G1Affine
+		compressed, uncompressed := p.BothBytes()
+		if compressed != p.Bytes() || uncompressed != p.RawBytes() {
+			t.Fatal("BothBytes() of infinity point should match Bytes() and RawBytes()")
+		}
+	}
+
+	// test MatchesCompressed
+	{
+		var p, q, pNeg, inf G1Affine
+		p = g1GenAff
+		q.ScalarMultiplication(&p, big.NewInt(2))
+		pNeg.Neg(&p)
+
+		buf := p.Bytes()
+		if ok, err := p.MatchesCompressed(buf[:]); err != nil || !ok {
+			t.Fatal("MatchesCompressed(Bytes()) should be true")
+		}
+		bufQ := q.Bytes()
+		if ok, err := p.MatchesCompressed(bufQ[:]); err != nil || ok {
+			t.Fatal("MatchesCompressed should be false on an encoding with a different x")
+		}
+		bufNeg := pNeg.Bytes()
+		if ok, err := p.MatchesCompressed(bufNeg[:]); err != nil || ok {
+			t.Fatal("MatchesCompressed should be false on an encoding with the opposite y-sign")
+		}
+		bufInf := inf.Bytes()
+		if ok, err := p.MatchesCompressed(bufInf[:]); err != nil || ok {
+			t.Fatal("MatchesCompressed should be false on the infinity encoding")
+		}
+		if ok, err := inf.MatchesCompressed(bufInf[:]); err != nil || !ok {
+			t.Fatal("MatchesCompressed(Bytes()) should be true for infinity")
+		}
+		bufRaw := p.RawBytes()
+		if _, err := p.MatchesCompressed(bufRaw[:]); err == nil {
+			t.Fatal("MatchesCompressed should fail on an uncompressed encoding")
+		}
+		if _, err := p.MatchesCompressed(buf[:SizeOfG1AffineCompressed-1]); err != io.ErrShortBuffer {
+			t.Fatal("MatchesCompressed should fail on a short buffer")
+		}
+	}
+
+	// test canonical encoding checks
+	{
+		var p1, p2 G1Affine
+		p1 = g1GenAff
+
+		// canonical encodings are accepted
+		buf := p1.RawBytes()
+		if _, err := p2.SetBytesCanonical(buf[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !p1.Equal(&p2) {
+			t.Fatal("SetBytesCanonical(RawBytes()) should stay the same")
+		}
+		bufCompressed := p1.Bytes()
+		if _, err := p2.SetBytesCanonical(bufCompressed[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !p1.Equal(&p2) {
+			t.Fatal("SetBytesCanonical(Bytes()) should stay the same")
+		}
+
+		// replace the last coordinate y by y+p, y+p+1, ...
+		// SetBytes reduces it, SetBytesCanonical rejects it
+		var y, k big.Int
+		y.SetBytes(buf[len(buf)-fp.Bytes:])
+		for i := int64(0); i < 3; i++ {
+			k.SetInt64(i).Add(&k, fp.Modulus()).Add(&k, &y)
+			nonCanonical := buf
+			k.FillBytes(nonCanonical[len(nonCanonical)-fp.Bytes:])
+
+			if _, err := p2.SetBytesCanonical(nonCanonical[:]); err == nil {
+				t.Fatal("SetBytesCanonical should reject a non-canonical coordinate")
+			}
+			_, err := p2.SetBytes(nonCanonical[:])
+			if i == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !p1.Equal(&p2) {
+					t.Fatal("SetBytes should reduce a non-canonical coordinate")
+				}
+			} else if err == nil {
+				t.Fatal("y+p+k with k != 0 should not be on the curve")
+			}
+		}
+
+		// infinity encodings are canonical
+		p1.X.SetZero()
+		p1.Y.SetZero()
+		buf = p1.RawBytes()
+		if _, err := p2.SetBytesCanonical(buf[:]); err != nil {
+			t.Fatal(err)
+		}
+		bufCompressed = p1.Bytes()
+		if _, err := p2.SetBytesCanonical(bufCompressed[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
 	parameters := gopter.DefaultTestParameters()
 	if testing.Short() {
 		parameters.MinSuccessfulTests = nbFuzzShort
@@ -278,6 +382,19 @@ func TestG1AffineSerialization(t *testing.T) {
 		GenFp(),
 	))
 
+	properties.Property("[G1] Affine BothBytes() should match Bytes() and RawBytes()", prop.ForAll(
+		func(a fp.Element) bool {
+			var p G1Affine
+			var ab big.Int
+			a.ToBigIntRegular(&ab)
+			p.ScalarMultiplication(&g1GenAff, &ab)
+
+			compressed, uncompressed := p.BothBytes()
+			return compressed == p.Bytes() && uncompressed == p.RawBytes()
+		},
+		GenFp(),
+	))
+
 	properties.Property("[G1] Affine SetBytes(Bytes()) should stay the same", prop.ForAll(
 		func(a fp.Element) bool {
 			var start, end G1Affine
@@ -301,6 +418,377 @@ func TestG1AffineSerialization(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestG1AffineUncompressedInfinity(t *testing.T) {
+	t.Parallel()
+
+	// the uncompressed encoding of infinity
+	expected := make([]byte, SizeOfG1AffineUncompressed)
+	expected[0] = mUncompressedInfinity
+	var inf G1Affine
+	raw := inf.RawBytes()
+	if !bytes.Equal(raw[:], expected) {
+		t.Fatalf("RawBytes of infinity should be %x, got %x", expected, raw)
+	}
+	if isCompressed(raw[0]) {
+		t.Fatal("the uncompressed encoding of infinity should not be flagged as compressed")
+	}
+
+	decoders := map[string]func(p *G1Affine) (int, error){
+		"SetBytes":          func(p *G1Affine) (int, error) { return p.SetBytes(raw[:]) },
+		"SetBytesCanonical": func(p *G1Affine) (int, error) { return p.SetBytesCanonical(raw[:]) },
+		"SetBytesLE": func(p *G1Affine) (int, error) {
+			le := inf.RawBytesLE()
+			return p.SetBytesLE(le[:])
+		},
+		"SetBytesUnsafe": func(p *G1Affine) (int, error) {
+			p.SetBytesUnsafe(raw[:])
+			return SizeOfG1AffineUncompressed, nil
+		},
+	}
+	for name, decode := range decoders {
+		p := g1GenAff
+		n, err := decode(&p)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if n != SizeOfG1AffineUncompressed || !p.IsInfinity() {
+			t.Fatalf("%s should decode the uncompressed infinity", name)
+		}
+	}
+
+	// streams mixing infinity and other points, with and without subgroup checks
+	in := []G1Affine{inf, g1GenAff, inf}
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf, RawEncoding())
+	if err := enc.Encode(&inf); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	for _, options := range [][]func(*Decoder){nil, {NoSubgroupChecks()}} {
+		dec := NewDecoder(bytes.NewReader(buf.Bytes()), options...)
+		p := g1GenAff
+		var out []G1Affine
+		if err := dec.Decode(&p); err != nil {
+			t.Fatal(err)
+		}
+		if err := dec.Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+		if !p.IsInfinity() || len(out) != len(in) {
+			t.Fatal("the decoder should read the uncompressed infinity")
+		}
+		for i := range in {
+			if !out[i].Equal(&in[i]) {
+				t.Fatal("the decoder should read slices with uncompressed infinity points")
+			}
+		}
+		if dec.BytesRead() != int64(buf.Len()) {
+			t.Fatal("the decoder should consume the whole stream")
+		}
+	}
+}
+
+func TestG1AffineSetBytesUnsafe(t *testing.T) {
+	t.Parallel()
+
+	// infinity
+	{
+		var p1, p2 G1Affine
+		p2.X.SetOne()
+		p2.Y.SetOne()
+		buf := p1.RawBytes()
+		p2.SetBytesUnsafe(buf[:])
+		if !p2.Equal(&p1) {
+			t.Fatal("SetBytesUnsafe(RawBytes) should round trip on infinity")
+		}
+	}
+
+	// random points
+	for i := 0; i < 100; i++ {
+		var p1, p2 G1Affine
+		p1.ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64()))
+		buf := p1.RawBytes()
+		p2.SetBytesUnsafe(buf[:])
+		if !p2.Equal(&p1) {
+			t.Fatal("SetBytesUnsafe(RawBytes) should round trip")
+		}
+
+		// matches the safe path
+		var p3 G1Affine
+		if _, err := p3.SetBytes(buf[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !p3.Equal(&p2) {
+			t.Fatal("SetBytesUnsafe and SetBytes should agree")
+		}
+	}
+}
+
+func TestG1AffineCompressionFlavor(t *testing.T) {
+	t.Parallel()
+
+	// sgn0 of y computed independently with big.Int
+	sgn0 := func(p *G1Affine) bool {
+		ys := []fp.Element{p.Y}
+		for _, y := range ys {
+			var b big.Int
+			y.ToBigIntRegular(&b)
+			if b.Sign() != 0 {
+				return b.Bit(0) == 1
+			}
+		}
+		return false
+	}
+
+	var inf G1Affine
+	points := []G1Affine{inf}
+	for i := 0; i < 50; i++ {
+		var p G1Affine
+		p.ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64()))
+		points = append(points, p)
+	}
+
+	for _, p := range points {
+		var neg G1Affine
+		neg.Neg(&p)
+
+		// the lexicographic flavor is the default encoding
+		lex, def := p.BytesWithFlavor(CompressionLexicographic), p.Bytes()
+		if lex != def {
+			t.Fatal("BytesWithFlavor(CompressionLexicographic) should match Bytes")
+		}
+
+		parity := p.BytesWithFlavor(CompressionParity)
+		if !p.IsInfinity() && (parity[0]&mMask == mCompressedLargest) != sgn0(&p) {
+			t.Fatal("the metadata bit of the parity flavor should be sgn0(y)")
+		}
+		if parity == lex && !p.IsInfinity() && sgn0(&p) != p.Y.LexicographicallyLargest() {
+			t.Fatal("the flavors should differ when sgn0(y) and the lexicographic sign differ")
+		}
+
+		for _, flavor := range []CompressionFlavor{CompressionLexicographic, CompressionParity} {
+			buf := p.BytesWithFlavor(flavor)
+			var q G1Affine
+			n, err := q.SetBytesWithFlavor(buf[:], flavor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != SizeOfG1AffineCompressed || !q.Equal(&p) {
+				t.Fatal("SetBytesWithFlavor(BytesWithFlavor) should round trip")
+			}
+
+			// uncompressed encodings are not affected by the flavor
+			raw := p.RawBytes()
+			if _, err := q.SetBytesWithFlavor(raw[:], flavor); err != nil || !q.Equal(&p) {
+				t.Fatal("SetBytesWithFlavor should decode uncompressed encodings")
+			}
+		}
+
+		// decoding with the other flavor yields ±p
+		var q G1Affine
+		if _, err := q.SetBytesWithFlavor(parity[:], CompressionLexicographic); err != nil {
+			t.Fatal(err)
+		}
+		if !q.Equal(&p) && !q.Equal(&neg) {
+			t.Fatal("decoding with the other flavor should yield ±p")
+		}
+	}
+
+	var q G1Affine
+	if _, err := q.SetBytesWithFlavor(nil, CompressionParity); err != io.ErrShortBuffer {
+		t.Fatal("SetBytesWithFlavor should fail on a short buffer")
+	}
+}
+
+func TestG1AffineSetBytesLenient(t *testing.T) {
+	t.Parallel()
+
+	// every infinity encoding, in the compressed and the uncompressed size
+	for _, flag := range []byte{0b000 << 5, 0b010 << 5, 0b110 << 5} {
+		for _, size := range []int{SizeOfG1AffineCompressed, SizeOfG1AffineUncompressed} {
+			buf := make([]byte, size)
+			buf[0] = flag
+			p := g1GenAff
+			n, err := p.SetBytesLenient(buf)
+			if err != nil {
+				t.Fatalf("flag %#x, %d bytes: %v", flag, size, err)
+			}
+			if n != size || !p.IsInfinity() {
+				t.Fatalf("flag %#x, %d bytes: should decode the point at infinity", flag, size)
+			}
+		}
+	}
+
+	// the encodings of this package are decoded as with SetBytes
+	var inf G1Affine
+	points := []G1Affine{inf}
+	for i := 0; i < 10; i++ {
+		var p G1Affine
+		p.ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64()))
+		points = append(points, p)
+	}
+	for _, p := range points {
+		compressed, uncompressed := p.Bytes(), p.RawBytes()
+		for _, buf := range [][]byte{compressed[:], uncompressed[:]} {
+			var q G1Affine
+			n, err := q.SetBytesLenient(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(buf) || !q.Equal(&p) {
+				t.Fatal("SetBytesLenient should decode the encodings of Bytes and RawBytes")
+			}
+		}
+	}
+
+	var q G1Affine
+	if _, err := q.SetBytesLenient(nil); err != io.ErrShortBuffer {
+		t.Fatal("SetBytesLenient should fail on a short buffer")
+	}
+}
+
+func TestG1AffineBytesLE(t *testing.T) {
+	t.Parallel()
+
+	reversed := func(b []byte) []byte {
+		r := make([]byte, len(b))
+		for i := range b {
+			r[i] = b[len(b)-1-i]
+		}
+		return r
+	}
+
+	var inf G1Affine
+	points := []G1Affine{inf}
+	for i := 0; i < 50; i++ {
+		var p G1Affine
+		p.ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64()))
+		points = append(points, p)
+	}
+
+	for _, p := range points {
+		be, le := p.Bytes(), p.BytesLE()
+		if !bytes.Equal(le[:], reversed(be[:])) {
+			t.Fatal("BytesLE should be the byte reverse of Bytes")
+		}
+		rawBE, rawLE := p.RawBytes(), p.RawBytesLE()
+		if !bytes.Equal(rawLE[:SizeOfG1AffineCompressed], reversed(rawBE[:SizeOfG1AffineCompressed])) ||
+			!bytes.Equal(rawLE[SizeOfG1AffineCompressed:], reversed(rawBE[SizeOfG1AffineCompressed:])) {
+			t.Fatal("RawBytesLE should be RawBytes with X and Y byte reversed")
+		}
+
+		for _, buf := range [][]byte{le[:], rawLE[:]} {
+			var q G1Affine
+			n, err := q.SetBytesLE(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(buf) {
+				t.Fatal("SetBytesLE should consume the whole encoding")
+			}
+			if !q.Equal(&p) {
+				t.Fatal("SetBytesLE should round trip")
+			}
+		}
+
+		var q G1Affine
+		if _, err := q.SetBytesLE(le[:SizeOfG1AffineCompressed-1]); err != io.ErrShortBuffer {
+			t.Fatal("SetBytesLE should fail on a short buffer")
+		}
+		if _, err := q.SetBytesLE(rawLE[:SizeOfG1AffineUncompressed-1]); err != io.ErrShortBuffer {
+			t.Fatal("SetBytesLE should fail on a short uncompressed buffer")
+		}
+	}
+}
+
+func TestG1AffineEncodeHexBech32(t *testing.T) {
+	t.Parallel()
+
+	var inf G1Affine
+	points := []G1Affine{inf}
+	for i := 0; i < 10; i++ {
+		var p G1Affine
+		p.ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64()))
+		points = append(points, p)
+	}
+
+	for _, p := range points {
+		// hex
+		var q G1Affine
+		s := p.EncodeHex()
+		if err := q.DecodeHex(s); err != nil {
+			t.Fatal(err)
+		}
+		if !q.Equal(&p) {
+			t.Fatal("DecodeHex should round trip")
+		}
+		if err := q.DecodeHex(s[:len(s)-2]); err == nil {
+			t.Fatal("DecodeHex should fail on a short encoding")
+		}
+		if err := q.DecodeHex(s + "00"); err == nil {
+			t.Fatal("DecodeHex should fail on trailing bytes")
+		}
+		if err := q.DecodeHex("x" + s[1:]); err == nil {
+			t.Fatal("DecodeHex should fail on a non hexadecimal string")
+		}
+
+		// bech32
+		s, err := p.EncodeBech32("pk")
+		if err != nil {
+			t.Fatal(err)
+		}
+		hrp, err := q.DecodeBech32(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hrp != "pk" || !q.Equal(&p) {
+			t.Fatal("DecodeBech32 should round trip")
+		}
+
+		// altered checksum
+		altered := []byte(s)
+		if altered[len(altered)-1] == 'q' {
+			altered[len(altered)-1] = 'p'
+		} else {
+			altered[len(altered)-1] = 'q'
+		}
+		if _, err := q.DecodeBech32(string(altered)); err == nil {
+			t.Fatal("DecodeBech32 should fail on an invalid checksum")
+		}
+	}
+
+	// uncompressed points are rejected
+	raw := points[1].RawBytes()
+	var q G1Affine
+	if err := q.DecodeHex(hex.EncodeToString(raw[:])); err == nil {
+		t.Fatal("DecodeHex should reject uncompressed points")
+	}
+
+	if _, err := points[1].EncodeBech32(""); err == nil {
+		t.Fatal("EncodeBech32 should fail on an empty prefix")
+	}
+}
+
+func BenchmarkG1AffineBytes(b *testing.B) {
+	var p G1Affine
+	p.ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64()))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Bytes()
+	}
+}
+
+func BenchmarkG1AffineRawBytes(b *testing.B) {
+	var p G1Affine
+	p.ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64()))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.RawBytes()
+	}
+}
+
 func TestG2AffineSerialization(t *testing.T) {
 	t.Parallel()
 	// test round trip serialization of infinity
@@ -342,6 +830,109 @@ func TestG2AffineSerialization(t *testing.T) {
 		}
 	}
 
+	// test BothBytes on infinity
+	{
+		var p G2Affine
+		compressed, uncompressed := p.BothBytes()
+		if compressed != p.Bytes() || uncompressed != p.RawBytes() {
+			t.Fatal("BothBytes() of infinity point should match Bytes() and RawBytes()")
+		}
+	}
+
+	// test MatchesCompressed
+	{
+		var p, q, pNeg, inf G2Affine
+		p = g2GenAff
+		q.ScalarMultiplication(&p, big.NewInt(2))
+		pNeg.Neg(&p)
+
+		buf := p.Bytes()
+		if ok, err := p.MatchesCompressed(buf[:]); err != nil || !ok {
+			t.Fatal("MatchesCompressed(Bytes()) should be true")
+		}
+		bufQ := q.Bytes()
+		if ok, err := p.MatchesCompressed(bufQ[:]); err != nil || ok {
+			t.Fatal("MatchesCompressed should be false on an encoding with a different x")
+		}
+		bufNeg := pNeg.Bytes()
+		if ok, err := p.MatchesCompressed(bufNeg[:]); err != nil || ok {
+			t.Fatal("MatchesCompressed should be false on an encoding with the opposite y-sign")
+		}
+		bufInf := inf.Bytes()
+		if ok, err := p.MatchesCompressed(bufInf[:]); err != nil || ok {
+			t.Fatal("MatchesCompressed should be false on the infinity encoding")
+		}
+		if ok, err := inf.MatchesCompressed(bufInf[:]); err != nil || !ok {
+			t.Fatal("MatchesCompressed(Bytes()) should be true for infinity")
+		}
+		bufRaw := p.RawBytes()
+		if _, err := p.MatchesCompressed(bufRaw[:]); err == nil {
+			t.Fatal("MatchesCompressed should fail on an uncompressed encoding")
+		}
+		if _, err := p.MatchesCompressed(buf[:SizeOfG2AffineCompressed-1]); err != io.ErrShortBuffer {
+			t.Fatal("MatchesCompressed should fail on a short buffer")
+		}
+	}
+
+	// test canonical encoding checks
+	{
+		var p1, p2 G2Affine
+		p1 = g2GenAff
+
+		// canonical encodings are accepted
+		buf := p1.RawBytes()
+		if _, err := p2.SetBytesCanonical(buf[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !p1.Equal(&p2) {
+			t.Fatal("SetBytesCanonical(RawBytes()) should stay the same")
+		}
+		bufCompressed := p1.Bytes()
+		if _, err := p2.SetBytesCanonical(bufCompressed[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !p1.Equal(&p2) {
+			t.Fatal("SetBytesCanonical(Bytes()) should stay the same")
+		}
+
+		// replace the last coordinate y by y+p, y+p+1, ...
+		// SetBytes reduces it, SetBytesCanonical rejects it
+		var y, k big.Int
+		y.SetBytes(buf[len(buf)-fp.Bytes:])
+		for i := int64(0); i < 3; i++ {
+			k.SetInt64(i).Add(&k, fp.Modulus()).Add(&k, &y)
+			nonCanonical := buf
+			k.FillBytes(nonCanonical[len(nonCanonical)-fp.Bytes:])
+
+			if _, err := p2.SetBytesCanonical(nonCanonical[:]); err == nil {
+				t.Fatal("SetBytesCanonical should reject a non-canonical coordinate")
+			}
+			_, err := p2.SetBytes(nonCanonical[:])
+			if i == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !p1.Equal(&p2) {
+					t.Fatal("SetBytes should reduce a non-canonical coordinate")
+				}
+			} else if err == nil {
+				t.Fatal("y+p+k with k != 0 should not be on the curve")
+			}
+		}
+
+		// infinity encodings are canonical
+		p1.X.SetZero()
+		p1.Y.SetZero()
+		buf = p1.RawBytes()
+		if _, err := p2.SetBytesCanonical(buf[:]); err != nil {
+			t.Fatal(err)
+		}
+		bufCompressed = p1.Bytes()
+		if _, err := p2.SetBytesCanonical(bufCompressed[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
 	parameters := gopter.DefaultTestParameters()
 	if testing.Short() {
 		parameters.MinSuccessfulTests = nbFuzzShort
@@ -371,6 +962,19 @@ func TestG2AffineSerialization(t *testing.T) {
 		GenFp(),
 	))
 
+	properties.Property("[G2] Affine BothBytes() should match Bytes() and RawBytes()", prop.ForAll(
+		func(a fp.Element) bool {
+			var p G2Affine
+			var ab big.Int
+			a.ToBigIntRegular(&ab)
+			p.ScalarMultiplication(&g2GenAff, &ab)
+
+			compressed, uncompressed := p.BothBytes()
+			return compressed == p.Bytes() && uncompressed == p.RawBytes()
+		},
+		GenFp(),
+	))
+
 	properties.Property("[G2] Affine SetBytes(Bytes()) should stay the same", prop.ForAll(
 		func(a fp.Element) bool {
 			var start, end G2Affine
@@ -394,6 +998,377 @@ func TestG2AffineSerialization(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestG2AffineUncompressedInfinity(t *testing.T) {
+	t.Parallel()
+
+	// the uncompressed encoding of infinity
+	expected := make([]byte, SizeOfG2AffineUncompressed)
+	expected[0] = mUncompressedInfinity
+	var inf G2Affine
+	raw := inf.RawBytes()
+	if !bytes.Equal(raw[:], expected) {
+		t.Fatalf("RawBytes of infinity should be %x, got %x", expected, raw)
+	}
+	if isCompressed(raw[0]) {
+		t.Fatal("the uncompressed encoding of infinity should not be flagged as compressed")
+	}
+
+	decoders := map[string]func(p *G2Affine) (int, error){
+		"SetBytes":          func(p *G2Affine) (int, error) { return p.SetBytes(raw[:]) },
+		"SetBytesCanonical": func(p *G2Affine) (int, error) { return p.SetBytesCanonical(raw[:]) },
+		"SetBytesLE": func(p *G2Affine) (int, error) {
+			le := inf.RawBytesLE()
+			return p.SetBytesLE(le[:])
+		},
+		"SetBytesUnsafe": func(p *G2Affine) (int, error) {
+			p.SetBytesUnsafe(raw[:])
+			return SizeOfG2AffineUncompressed, nil
+		},
+	}
+	for name, decode := range decoders {
+		p := g2GenAff
+		n, err := decode(&p)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if n != SizeOfG2AffineUncompressed || !p.IsInfinity() {
+			t.Fatalf("%s should decode the uncompressed infinity", name)
+		}
+	}
+
+	// streams mixing infinity and other points, with and without subgroup checks
+	in := []G2Affine{inf, g2GenAff, inf}
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf, RawEncoding())
+	if err := enc.Encode(&inf); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	for _, options := range [][]func(*Decoder){nil, {NoSubgroupChecks()}} {
+		dec := NewDecoder(bytes.NewReader(buf.Bytes()), options...)
+		p := g2GenAff
+		var out []G2Affine
+		if err := dec.Decode(&p); err != nil {
+			t.Fatal(err)
+		}
+		if err := dec.Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+		if !p.IsInfinity() || len(out) != len(in) {
+			t.Fatal("the decoder should read the uncompressed infinity")
+		}
+		for i := range in {
+			if !out[i].Equal(&in[i]) {
+				t.Fatal("the decoder should read slices with uncompressed infinity points")
+			}
+		}
+		if dec.BytesRead() != int64(buf.Len()) {
+			t.Fatal("the decoder should consume the whole stream")
+		}
+	}
+}
+
+func TestG2AffineSetBytesUnsafe(t *testing.T) {
+	t.Parallel()
+
+	// infinity
+	{
+		var p1, p2 G2Affine
+		p2.X.SetOne()
+		p2.Y.SetOne()
+		buf := p1.RawBytes()
+		p2.SetBytesUnsafe(buf[:])
+		if !p2.Equal(&p1) {
+			t.Fatal("SetBytesUnsafe(RawBytes) should round trip on infinity")
+		}
+	}
+
+	// random points
+	for i := 0; i < 100; i++ {
+		var p1, p2 G2Affine
+		p1.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64()))
+		buf := p1.RawBytes()
+		p2.SetBytesUnsafe(buf[:])
+		if !p2.Equal(&p1) {
+			t.Fatal("SetBytesUnsafe(RawBytes) should round trip")
+		}
+
+		// matches the safe path
+		var p3 G2Affine
+		if _, err := p3.SetBytes(buf[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !p3.Equal(&p2) {
+			t.Fatal("SetBytesUnsafe and SetBytes should agree")
+		}
+	}
+}
+
+func TestG2AffineCompressionFlavor(t *testing.T) {
+	t.Parallel()
+
+	// sgn0 of y computed independently with big.Int
+	sgn0 := func(p *G2Affine) bool {
+		ys := []fp.Element{p.Y.A0, p.Y.A1}
+		for _, y := range ys {
+			var b big.Int
+			y.ToBigIntRegular(&b)
+			if b.Sign() != 0 {
+				return b.Bit(0) == 1
+			}
+		}
+		return false
+	}
+
+	var inf G2Affine
+	points := []G2Affine{inf}
+	for i := 0; i < 50; i++ {
+		var p G2Affine
+		p.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64()))
+		points = append(points, p)
+	}
+
+	for _, p := range points {
+		var neg G2Affine
+		neg.Neg(&p)
+
+		// the lexicographic flavor is the default encoding
+		lex, def := p.BytesWithFlavor(CompressionLexicographic), p.Bytes()
+		if lex != def {
+			t.Fatal("BytesWithFlavor(CompressionLexicographic) should match Bytes")
+		}
+
+		parity := p.BytesWithFlavor(CompressionParity)
+		if !p.IsInfinity() && (parity[0]&mMask == mCompressedLargest) != sgn0(&p) {
+			t.Fatal("the metadata bit of the parity flavor should be sgn0(y)")
+		}
+		if parity == lex && !p.IsInfinity() && sgn0(&p) != p.Y.LexicographicallyLargest() {
+			t.Fatal("the flavors should differ when sgn0(y) and the lexicographic sign differ")
+		}
+
+		for _, flavor := range []CompressionFlavor{CompressionLexicographic, CompressionParity} {
+			buf := p.BytesWithFlavor(flavor)
+			var q G2Affine
+			n, err := q.SetBytesWithFlavor(buf[:], flavor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != SizeOfG2AffineCompressed || !q.Equal(&p) {
+				t.Fatal("SetBytesWithFlavor(BytesWithFlavor) should round trip")
+			}
+
+			// uncompressed encodings are not affected by the flavor
+			raw := p.RawBytes()
+			if _, err := q.SetBytesWithFlavor(raw[:], flavor); err != nil || !q.Equal(&p) {
+				t.Fatal("SetBytesWithFlavor should decode uncompressed encodings")
+			}
+		}
+
+		// decoding with the other flavor yields ±p
+		var q G2Affine
+		if _, err := q.SetBytesWithFlavor(parity[:], CompressionLexicographic); err != nil {
+			t.Fatal(err)
+		}
+		if !q.Equal(&p) && !q.Equal(&neg) {
+			t.Fatal("decoding with the other flavor should yield ±p")
+		}
+	}
+
+	var q G2Affine
+	if _, err := q.SetBytesWithFlavor(nil, CompressionParity); err != io.ErrShortBuffer {
+		t.Fatal("SetBytesWithFlavor should fail on a short buffer")
+	}
+}
+
+func TestG2AffineSetBytesLenient(t *testing.T) {
+	t.Parallel()
+
+	// every infinity encoding, in the compressed and the uncompressed size
+	for _, flag := range []byte{0b000 << 5, 0b010 << 5, 0b110 << 5} {
+		for _, size := range []int{SizeOfG2AffineCompressed, SizeOfG2AffineUncompressed} {
+			buf := make([]byte, size)
+			buf[0] = flag
+			p := g2GenAff
+			n, err := p.SetBytesLenient(buf)
+			if err != nil {
+				t.Fatalf("flag %#x, %d bytes: %v", flag, size, err)
+			}
+			if n != size || !p.IsInfinity() {
+				t.Fatalf("flag %#x, %d bytes: should decode the point at infinity", flag, size)
+			}
+		}
+	}
+
+	// the encodings of this package are decoded as with SetBytes
+	var inf G2Affine
+	points := []G2Affine{inf}
+	for i := 0; i < 10; i++ {
+		var p G2Affine
+		p.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64()))
+		points = append(points, p)
+	}
+	for _, p := range points {
+		compressed, uncompressed := p.Bytes(), p.RawBytes()
+		for _, buf := range [][]byte{compressed[:], uncompressed[:]} {
+			var q G2Affine
+			n, err := q.SetBytesLenient(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(buf) || !q.Equal(&p) {
+				t.Fatal("SetBytesLenient should decode the encodings of Bytes and RawBytes")
+			}
+		}
+	}
+
+	var q G2Affine
+	if _, err := q.SetBytesLenient(nil); err != io.ErrShortBuffer {
+		t.Fatal("SetBytesLenient should fail on a short buffer")
+	}
+}
+
+func TestG2AffineBytesLE(t *testing.T) {
+	t.Parallel()
+
+	reversed := func(b []byte) []byte {
+		r := make([]byte, len(b))
+		for i := range b {
+			r[i] = b[len(b)-1-i]
+		}
+		return r
+	}
+
+	var inf G2Affine
+	points := []G2Affine{inf}
+	for i := 0; i < 50; i++ {
+		var p G2Affine
+		p.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64()))
+		points = append(points, p)
+	}
+
+	for _, p := range points {
+		be, le := p.Bytes(), p.BytesLE()
+		if !bytes.Equal(le[:], reversed(be[:])) {
+			t.Fatal("BytesLE should be the byte reverse of Bytes")
+		}
+		rawBE, rawLE := p.RawBytes(), p.RawBytesLE()
+		if !bytes.Equal(rawLE[:SizeOfG2AffineCompressed], reversed(rawBE[:SizeOfG2AffineCompressed])) ||
+			!bytes.Equal(rawLE[SizeOfG2AffineCompressed:], reversed(rawBE[SizeOfG2AffineCompressed:])) {
+			t.Fatal("RawBytesLE should be RawBytes with X and Y byte reversed")
+		}
+
+		for _, buf := range [][]byte{le[:], rawLE[:]} {
+			var q G2Affine
+			n, err := q.SetBytesLE(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(buf) {
+				t.Fatal("SetBytesLE should consume the whole encoding")
+			}
+			if !q.Equal(&p) {
+				t.Fatal("SetBytesLE should round trip")
+			}
+		}
+
+		var q G2Affine
+		if _, err := q.SetBytesLE(le[:SizeOfG2AffineCompressed-1]); err != io.ErrShortBuffer {
+			t.Fatal("SetBytesLE should fail on a short buffer")
+		}
+		if _, err := q.SetBytesLE(rawLE[:SizeOfG2AffineUncompressed-1]); err != io.ErrShortBuffer {
+			t.Fatal("SetBytesLE should fail on a short uncompressed buffer")
+		}
+	}
+}
+
+func TestG2AffineEncodeHexBech32(t *testing.T) {
+	t.Parallel()
+
+	var inf G2Affine
+	points := []G2Affine{inf}
+	for i := 0; i < 10; i++ {
+		var p G2Affine
+		p.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64()))
+		points = append(points, p)
+	}
+
+	for _, p := range points {
+		// hex
+		var q G2Affine
+		s := p.EncodeHex()
+		if err := q.DecodeHex(s); err != nil {
+			t.Fatal(err)
+		}
+		if !q.Equal(&p) {
+			t.Fatal("DecodeHex should round trip")
+		}
+		if err := q.DecodeHex(s[:len(s)-2]); err == nil {
+			t.Fatal("DecodeHex should fail on a short encoding")
+		}
+		if err := q.DecodeHex(s + "00"); err == nil {
+			t.Fatal("DecodeHex should fail on trailing bytes")
+		}
+		if err := q.DecodeHex("x" + s[1:]); err == nil {
+			t.Fatal("DecodeHex should fail on a non hexadecimal string")
+		}
+
+		// bech32
+		s, err := p.EncodeBech32("pk")
+		if err != nil {
+			t.Fatal(err)
+		}
+		hrp, err := q.DecodeBech32(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hrp != "pk" || !q.Equal(&p) {
+			t.Fatal("DecodeBech32 should round trip")
+		}
+
+		// altered checksum
+		altered := []byte(s)
+		if altered[len(altered)-1] == 'q' {
+			altered[len(altered)-1] = 'p'
+		} else {
+			altered[len(altered)-1] = 'q'
+		}
+		if _, err := q.DecodeBech32(string(altered)); err == nil {
+			t.Fatal("DecodeBech32 should fail on an invalid checksum")
+		}
+	}
+
+	// uncompressed points are rejected
+	raw := points[1].RawBytes()
+	var q G2Affine
+	if err := q.DecodeHex(hex.EncodeToString(raw[:])); err == nil {
+		t.Fatal("DecodeHex should reject uncompressed points")
+	}
+
+	if _, err := points[1].EncodeBech32(""); err == nil {
+		t.Fatal("EncodeBech32 should fail on an empty prefix")
+	}
+}
+
+func BenchmarkG2AffineBytes(b *testing.B) {
+	var p G2Affine
+	p.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64()))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Bytes()
+	}
+}
+
+func BenchmarkG2AffineRawBytes(b *testing.B) {
+	var p G2Affine
+	p.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64()))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.RawBytes()
+	}
+}
+
 // define Gopters generators
 
 // GenFr generates an Fr element
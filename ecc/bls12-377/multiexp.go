@@ -22,9 +22,13 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/internal/parallel"
 	"math"
+	"math/big"
 	"runtime"
 )
 
+// ErrMultiExpSize is returned by MultiExp when len(points) != len(scalars)
+var ErrMultiExpSize = errors.New("len(points) != len(scalars)")
+
 // selector stores the index, mask and shifts needed to select bits from a scalar
 // it is used during the multiExp algorithm or the batch scalar multiplication
 type selector struct {
@@ -174,6 +178,25 @@ func (p *G1Affine) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.
 	return p, nil
 }
 
+// MultiExpAffineG1 returns the multi exponentiation ∑ scalars[i]⋅points[i] in affine coordinates,
+// see G1Jac.MultiExp.
+//
+// config is optional, the zero ecc.MultiExpConfig is used by default (in particular, scalars are
+// expected in regular form unless config.ScalarsMont is set).
+func MultiExpAffineG1(points []G1Affine, scalars []fr.Element, config ...ecc.MultiExpConfig) (G1Affine, error) {
+	var cfg ecc.MultiExpConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	var res G1Affine
+	var _p G1Jac
+	if _, err := _p.MultiExp(points, scalars, cfg); err != nil {
+		return res, err
+	}
+	res.FromJacobian(&_p)
+	return res, nil
+}
+
 // MultiExp implements section 4 of https://eprint.iacr.org/2012/549.pdf
 //
 // This call return an error if len(scalars) != len(points) or if provided config is invalid.
@@ -207,7 +230,7 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 	// ensure len(points) == len(scalars)
 	nbPoints := len(points)
 	if nbPoints != len(scalars) {
-		return nil, errors.New("len(points) != len(scalars)")
+		return nil, ErrMultiExpSize
 	}
 
 	// if nbTasks is not set, use all available CPUs
@@ -217,6 +240,22 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 		return nil, errors.New("invalid config: config.NbTasks > 1024")
 	}
 
+	// no need to split the work for 0 or 1 point
+	switch nbPoints {
+	case 0:
+		p.Set(&g1Infinity)
+		return p, nil
+	case 1:
+		var s big.Int
+		if config.ScalarsMont {
+			scalars[0].ToBigIntRegular(&s)
+		} else {
+			scalars[0].ToBigInt(&s)
+		}
+		p.ScalarMultiplicationAffine(&points[0], &s)
+		return p, nil
+	}
+
 	// here, we compute the best C for nbPoints
 	// we split recursively until nbChunks(c) >= nbTasks,
 	bestC := func(nbPoints int) uint64 {
@@ -360,6 +399,22 @@ func msmReduceChunkG1Affine(p *G1Jac, c int, chChunks []chan g1JacExtended) *G1J
 	return p.unsafeFromJacExtended(&_p)
 }
 
+// condNegAndAddG1Affine accumulates ±p in the bucket of the signed digit encoded in bits (see partitionScalars).
+//
+// If the msbWindow bit is not set, bits is a digit d > 0 and p is added to buckets[d-1];
+// otherwise bits = (-d-1) | msbWindow for a digit d < 0 and p is subtracted from buckets[-d-1].
+// bits must not be zero.
+func condNegAndAddG1Affine(buckets []g1JacExtended, p *G1Affine, bits, msbWindow uint64) {
+	// if msbWindow bit is set, we need to substract
+	if bits&msbWindow == 0 {
+		// add
+		buckets[bits-1].addMixed(p)
+	} else {
+		// sub
+		buckets[bits & ^msbWindow].subMixed(p)
+	}
+}
+
 func msmProcessChunkG1Affine(chunk uint64,
 	chRes chan<- g1JacExtended,
 	buckets []g1JacExtended,
@@ -397,14 +452,7 @@ func msmProcessChunkG1Affine(chunk uint64,
 			continue
 		}
 
-		// if msbWindow bit is set, we need to substract
-		if bits&msbWindow == 0 {
-			// add
-			buckets[bits-1].addMixed(&points[i])
-		} else {
-			// sub
-			buckets[bits & ^msbWindow].subMixed(&points[i])
-		}
+		condNegAndAddG1Affine(buckets, &points[i], bits, msbWindow)
 	}
 
 	// reduce buckets into total
@@ -1195,6 +1243,25 @@ func (p *G2Affine) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.
 	return p, nil
 }
 
+// MultiExpAffineG2 returns the multi exponentiation ∑ scalars[i]⋅points[i] in affine coordinates,
+// see G2Jac.MultiExp.
+//
+// config is optional, the zero ecc.MultiExpConfig is used by default (in particular, scalars are
+// expected in regular form unless config.ScalarsMont is set).
+func MultiExpAffineG2(points []G2Affine, scalars []fr.Element, config ...ecc.MultiExpConfig) (G2Affine, error) {
+	var cfg ecc.MultiExpConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	var res G2Affine
+	var _p G2Jac
+	if _, err := _p.MultiExp(points, scalars, cfg); err != nil {
+		return res, err
+	}
+	res.FromJacobian(&_p)
+	return res, nil
+}
+
 // MultiExp implements section 4 of https://eprint.iacr.org/2012/549.pdf
 //
 // This call return an error if len(scalars) != len(points) or if provided config is invalid.
@@ -1228,7 +1295,7 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 	// ensure len(points) == len(scalars)
 	nbPoints := len(points)
 	if nbPoints != len(scalars) {
-		return nil, errors.New("len(points) != len(scalars)")
+		return nil, ErrMultiExpSize
 	}
 
 	// if nbTasks is not set, use all available CPUs
@@ -1238,6 +1305,22 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 		return nil, errors.New("invalid config: config.NbTasks > 1024")
 	}
 
+	// no need to split the work for 0 or 1 point
+	switch nbPoints {
+	case 0:
+		p.Set(&g2Infinity)
+		return p, nil
+	case 1:
+		var s big.Int
+		if config.ScalarsMont {
+			scalars[0].ToBigIntRegular(&s)
+		} else {
+			scalars[0].ToBigInt(&s)
+		}
+		p.ScalarMultiplicationAffine(&points[0], &s)
+		return p, nil
+	}
+
 	// here, we compute the best C for nbPoints
 	// we split recursively until nbChunks(c) >= nbTasks,
 	bestC := func(nbPoints int) uint64 {
@@ -1381,6 +1464,22 @@ func msmReduceChunkG2Affine(p *G2Jac, c int, chChunks []chan g2JacExtended) *G2J
 	return p.unsafeFromJacExtended(&_p)
 }
 
+// condNegAndAddG2Affine accumulates ±p in the bucket of the signed digit encoded in bits (see partitionScalars).
+//
+// If the msbWindow bit is not set, bits is a digit d > 0 and p is added to buckets[d-1];
+// otherwise bits = (-d-1) | msbWindow for a digit d < 0 and p is subtracted from buckets[-d-1].
+// bits must not be zero.
+func condNegAndAddG2Affine(buckets []g2JacExtended, p *G2Affine, bits, msbWindow uint64) {
+	// if msbWindow bit is set, we need to substract
+	if bits&msbWindow == 0 {
+		// add
+		buckets[bits-1].addMixed(p)
+	} else {
+		// sub
+		buckets[bits & ^msbWindow].subMixed(p)
+	}
+}
+
 func msmProcessChunkG2Affine(chunk uint64,
 	chRes chan<- g2JacExtended,
 	buckets []g2JacExtended,
@@ -1418,14 +1517,7 @@ func msmProcessChunkG2Affine(chunk uint64,
 			continue
 		}
 
-		// if msbWindow bit is set, we need to substract
-		if bits&msbWindow == 0 {
-			// add
-			buckets[bits-1].addMixed(&points[i])
-		} else {
-			// sub
-			buckets[bits & ^msbWindow].subMixed(&points[i])
-		}
+		condNegAndAddG2Affine(buckets, &points[i], bits, msbWindow)
 	}
 
 	// reduce buckets into total
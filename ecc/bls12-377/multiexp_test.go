@@ -172,9 +172,156 @@ func TestMultiExpG1(t *testing.T) {
 		genScalar,
 	))
 
+	properties.Property("[G1] MultiExpAffineG1 should be equal to FromJacobian(MultiExp)", prop.ForAll(
+		func(mixer fr.Element) bool {
+
+			var g G1Jac
+			g.Set(&g1Gen)
+
+			samplePoints := make([]G1Affine, 30)
+			sampleScalars := make([]fr.Element, 30)
+
+			for i := 1; i <= 30; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+				samplePoints[i-1].FromJacobian(&g)
+				g.AddAssign(&g1Gen)
+			}
+
+			config := ecc.MultiExpConfig{ScalarsMont: true}
+			var jac G1Jac
+			var expected G1Affine
+			if _, err := jac.MultiExp(samplePoints, sampleScalars, config); err != nil {
+				return false
+			}
+			expected.FromJacobian(&jac)
+
+			res, err := MultiExpAffineG1(samplePoints, sampleScalars, config)
+			if err != nil || !res.Equal(&expected) {
+				return false
+			}
+
+			// default config: scalars in regular form
+			for i := range sampleScalars {
+				sampleScalars[i].FromMont()
+			}
+			res, err = MultiExpAffineG1(samplePoints, sampleScalars)
+			if err != nil || !res.Equal(&expected) {
+				return false
+			}
+
+			// mismatching sizes
+			_, err = MultiExpAffineG1(samplePoints[1:], sampleScalars)
+			return err != nil
+		},
+		genScalar,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+// TestMultiExpEdgeCasesG1Jac checks the shortcuts of MultiExp for 0 and 1 point,
+// and that mismatching sizes return ErrMultiExpSize
+func TestMultiExpEdgeCasesG1Jac(t *testing.T) {
+	t.Parallel()
+
+	var p G1Jac
+	p.Set(&g1Gen)
+
+	// no point: infinity
+	if _, err := p.MultiExp(nil, nil, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Z.IsZero() {
+		t.Fatal("MultiExp of 0 point should be infinity")
+	}
+
+	// one point: scalar multiplication, with scalars in regular or montgomery form
+	var s fr.Element
+	s.SetUint64(42)
+	var expected G1Jac
+	expected.ScalarMultiplicationAffine(&g1GenAff, big.NewInt(42))
+
+	points := []G1Affine{g1GenAff}
+	if _, err := p.MultiExp(points, []fr.Element{s}, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(&expected) {
+		t.Fatal("MultiExp of 1 point (montgomery scalar) should match ScalarMultiplication")
+	}
+	s.FromMont()
+	if _, err := p.MultiExp(points, []fr.Element{s}, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(&expected) {
+		t.Fatal("MultiExp of 1 point (regular scalar) should match ScalarMultiplication")
+	}
+
+	// mismatching sizes
+	if _, err := p.MultiExp(points, nil, ecc.MultiExpConfig{}); err != ErrMultiExpSize {
+		t.Fatal("expected ErrMultiExpSize")
+	}
+	var a G1Affine
+	if _, err := a.MultiExp(nil, []fr.Element{s}, ecc.MultiExpConfig{}); err != ErrMultiExpSize {
+		t.Fatal("expected ErrMultiExpSize")
+	}
+}
+
+// TestCondNegAndAddG1Affine checks that accumulating p with each signed digit d in its bucket,
+// then reducing the buckets as in msmProcessChunk, yields [d]p
+func TestCondNegAndAddG1Affine(t *testing.T) {
+	t.Parallel()
+
+	var p G1Affine
+	p.ScalarMultiplication(&g1GenAff, big.NewInt(7))
+
+	for _, c := range []uint64{2, 3, 4, 5, 8} {
+		msbWindow := uint64(1 << (c - 1))
+		max := int(1 << (c - 1))
+		buckets := make([]g1JacExtended, 1<<(c-1))
+
+		for d := -max; d < max; d++ {
+			if d == 0 {
+				continue
+			}
+			var bits uint64
+			if d >= 0 {
+				bits = uint64(d)
+			} else {
+				bits = uint64(-d-1) | msbWindow
+			}
+
+			for k := range buckets {
+				buckets[k].setInfinity()
+			}
+			condNegAndAddG1Affine(buckets, &p, bits, msbWindow)
+
+			// total = bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+			var runningSum, total g1JacExtended
+			runningSum.setInfinity()
+			total.setInfinity()
+			for k := len(buckets) - 1; k >= 0; k-- {
+				if !buckets[k].ZZ.IsZero() {
+					runningSum.add(&buckets[k])
+				}
+				total.add(&runningSum)
+			}
+			var res, expected G1Affine
+			res.fromJacExtended(&total)
+
+			if d > 0 {
+				expected.ScalarMultiplication(&p, big.NewInt(int64(d)))
+			} else {
+				expected.ScalarMultiplication(&p, big.NewInt(int64(-d)))
+				expected.Neg(&expected)
+			}
+			if !res.Equal(&expected) {
+				t.Fatalf("c=%d: digit %d should accumulate [%d]p", c, d, d)
+			}
+		}
+	}
+}
+
 func BenchmarkMultiExpG1(b *testing.B) {
 
 	const (
@@ -415,9 +562,156 @@ func TestMultiExpG2(t *testing.T) {
 		genScalar,
 	))
 
+	properties.Property("[G2] MultiExpAffineG2 should be equal to FromJacobian(MultiExp)", prop.ForAll(
+		func(mixer fr.Element) bool {
+
+			var g G2Jac
+			g.Set(&g2Gen)
+
+			samplePoints := make([]G2Affine, 30)
+			sampleScalars := make([]fr.Element, 30)
+
+			for i := 1; i <= 30; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+				samplePoints[i-1].FromJacobian(&g)
+				g.AddAssign(&g2Gen)
+			}
+
+			config := ecc.MultiExpConfig{ScalarsMont: true}
+			var jac G2Jac
+			var expected G2Affine
+			if _, err := jac.MultiExp(samplePoints, sampleScalars, config); err != nil {
+				return false
+			}
+			expected.FromJacobian(&jac)
+
+			res, err := MultiExpAffineG2(samplePoints, sampleScalars, config)
+			if err != nil || !res.Equal(&expected) {
+				return false
+			}
+
+			// default config: scalars in regular form
+			for i := range sampleScalars {
+				sampleScalars[i].FromMont()
+			}
+			res, err = MultiExpAffineG2(samplePoints, sampleScalars)
+			if err != nil || !res.Equal(&expected) {
+				return false
+			}
+
+			// mismatching sizes
+			_, err = MultiExpAffineG2(samplePoints[1:], sampleScalars)
+			return err != nil
+		},
+		genScalar,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+// TestMultiExpEdgeCasesG2Jac checks the shortcuts of MultiExp for 0 and 1 point,
+// and that mismatching sizes return ErrMultiExpSize
+func TestMultiExpEdgeCasesG2Jac(t *testing.T) {
+	t.Parallel()
+
+	var p G2Jac
+	p.Set(&g2Gen)
+
+	// no point: infinity
+	if _, err := p.MultiExp(nil, nil, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Z.IsZero() {
+		t.Fatal("MultiExp of 0 point should be infinity")
+	}
+
+	// one point: scalar multiplication, with scalars in regular or montgomery form
+	var s fr.Element
+	s.SetUint64(42)
+	var expected G2Jac
+	expected.ScalarMultiplicationAffine(&g2GenAff, big.NewInt(42))
+
+	points := []G2Affine{g2GenAff}
+	if _, err := p.MultiExp(points, []fr.Element{s}, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(&expected) {
+		t.Fatal("MultiExp of 1 point (montgomery scalar) should match ScalarMultiplication")
+	}
+	s.FromMont()
+	if _, err := p.MultiExp(points, []fr.Element{s}, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(&expected) {
+		t.Fatal("MultiExp of 1 point (regular scalar) should match ScalarMultiplication")
+	}
+
+	// mismatching sizes
+	if _, err := p.MultiExp(points, nil, ecc.MultiExpConfig{}); err != ErrMultiExpSize {
+		t.Fatal("expected ErrMultiExpSize")
+	}
+	var a G2Affine
+	if _, err := a.MultiExp(nil, []fr.Element{s}, ecc.MultiExpConfig{}); err != ErrMultiExpSize {
+		t.Fatal("expected ErrMultiExpSize")
+	}
+}
+
+// TestCondNegAndAddG2Affine checks that accumulating p with each signed digit d in its bucket,
+// then reducing the buckets as in msmProcessChunk, yields [d]p
+func TestCondNegAndAddG2Affine(t *testing.T) {
+	t.Parallel()
+
+	var p G2Affine
+	p.ScalarMultiplication(&g2GenAff, big.NewInt(7))
+
+	for _, c := range []uint64{2, 3, 4, 5, 8} {
+		msbWindow := uint64(1 << (c - 1))
+		max := int(1 << (c - 1))
+		buckets := make([]g2JacExtended, 1<<(c-1))
+
+		for d := -max; d < max; d++ {
+			if d == 0 {
+				continue
+			}
+			var bits uint64
+			if d >= 0 {
+				bits = uint64(d)
+			} else {
+				bits = uint64(-d-1) | msbWindow
+			}
+
+			for k := range buckets {
+				buckets[k].setInfinity()
+			}
+			condNegAndAddG2Affine(buckets, &p, bits, msbWindow)
+
+			// total = bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+			var runningSum, total g2JacExtended
+			runningSum.setInfinity()
+			total.setInfinity()
+			for k := len(buckets) - 1; k >= 0; k-- {
+				if !buckets[k].ZZ.IsZero() {
+					runningSum.add(&buckets[k])
+				}
+				total.add(&runningSum)
+			}
+			var res, expected G2Affine
+			res.fromJacExtended(&total)
+
+			if d > 0 {
+				expected.ScalarMultiplication(&p, big.NewInt(int64(d)))
+			} else {
+				expected.ScalarMultiplication(&p, big.NewInt(int64(-d)))
+				expected.Neg(&expected)
+			}
+			if !res.Equal(&expected) {
+				t.Fatalf("c=%d: digit %d should accumulate [%d]p", c, d, d)
+			}
+		}
+	}
+}
+
 func BenchmarkMultiExpG2(b *testing.B) {
 
 	const (
@@ -518,6 +812,53 @@ func fillBenchBasesG2(samplePoints []G2Affine) {
 	}
 }
 
+// TestPartitionScalars checks that the signed c-bit digits computed by partitionScalars,
+// decoded as in msmProcessChunk, recompose the scalars: Σⱼ dⱼ ⋅ 2ᶜʲ = s
+func TestPartitionScalars(t *testing.T) {
+	t.Parallel()
+
+	const nbScalars = 64
+	scalars := make([]fr.Element, nbScalars)
+	for i := 2; i < nbScalars; i++ {
+		scalars[i].SetRandom()
+	}
+	// edge cases: 0, 1, r-1
+	scalars[1].SetOne()
+	scalars[nbScalars-1].SetOne().Neg(&scalars[nbScalars-1])
+
+	for _, c := range []uint64{3, 4, 5, 8, 11, 16, 21} {
+		partitioned, _ := partitionScalars(scalars, c, true, 1)
+		nbChunks := (fr.Limbs*64 + c - 1) / c
+		mask := new(big.Int).SetUint64((1 << c) - 1)
+		msbWindow := uint64(1 << (c - 1))
+
+		for i := range scalars {
+			var packed big.Int
+			for k := fr.Limbs - 1; k >= 0; k-- {
+				packed.Lsh(&packed, 64).Or(&packed, new(big.Int).SetUint64(partitioned[i][k]))
+			}
+
+			var res, digit, window big.Int
+			for chunk := int(nbChunks) - 1; chunk >= 0; chunk-- {
+				window.Rsh(&packed, uint(chunk)*uint(c)).And(&window, mask)
+				bits := window.Uint64()
+				if bits&msbWindow == 0 {
+					digit.SetUint64(bits)
+				} else {
+					digit.SetUint64((bits & ^msbWindow) + 1).Neg(&digit)
+				}
+				res.Lsh(&res, uint(c)).Add(&res, &digit)
+			}
+
+			var expected big.Int
+			scalars[i].ToBigIntRegular(&expected)
+			if res.Cmp(&expected) != 0 {
+				t.Fatalf("c=%d: digits of scalar %d recompose %s, expected %s", c, i, res.String(), expected.String())
+			}
+		}
+	}
+}
+
 func fillBenchScalars(sampleScalars []fr.Element) {
 	// ensure every words of the scalars are filled
 	var mixer fr.Element
@@ -0,0 +1,24 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package schnorr provides Schnorr signatures over bls12-377's G1.
+//
+// A signature of m under the public key P = x ⋅ G₁ is (R, s), with R = k ⋅ G₁ for a nonce k
+// derived deterministically from x and m, and s = k + c ⋅ x mod r where c = H(R, P, m).
+// It is valid if s ⋅ G₁ = R + c ⋅ P.
+//
+// See https://en.wikipedia.org/wiki/Schnorr_signature.
+package schnorr
@@ -0,0 +1,105 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package schnorr
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+var errInconsistentKey = errors.New("invalid private key: public key doesn't match the scalar")
+
+const (
+	sizePublicKey = bls12377.SizeOfG1AffineCompressed
+
+	// SizeSignature is the size in bytes of a signature, R (compressed) ∥ s
+	SizeSignature = sizePublicKey + fr.Bytes
+
+	// SizePrivateKey is the size in bytes of a private key, P (compressed) ∥ x
+	SizePrivateKey = sizePublicKey + fr.Bytes
+)
+
+// Bytes returns the binary representation of sig as R ∥ s, where R is compressed
+// (see G1Affine.Bytes) and s is in big endian.
+func (sig *Signature) Bytes() []byte {
+	var res [SizeSignature]byte
+	rb := sig.R.Bytes()
+	sb := sig.S.Bytes()
+	copy(res[:sizePublicKey], rb[:])
+	copy(res[sizePublicKey:], sb[:])
+	return res[:]
+}
+
+// SetBytes sets sig from buf, as returned by Bytes.
+// R must be in G1 and s must be canonical (smaller than r).
+// It returns the number of bytes read from buf.
+func (sig *Signature) SetBytes(buf []byte) (int, error) {
+	if len(buf) < SizeSignature {
+		return 0, io.ErrShortBuffer
+	}
+	if _, err := sig.R.SetBytes(buf[:sizePublicKey]); err != nil {
+		return 0, err
+	}
+	if err := sig.S.SetBytesCanonical(buf[sizePublicKey:SizeSignature]); err != nil {
+		return 0, err
+	}
+	return SizeSignature, nil
+}
+
+// Bytes returns the binary representation of privKey as P ∥ x, where P is compressed
+// (see G1Affine.Bytes) and x is in big endian.
+func (privKey *PrivateKey) Bytes() []byte {
+	var res [SizePrivateKey]byte
+	pb := privKey.PublicKey.Bytes()
+	xb := privKey.scalar.Bytes()
+	copy(res[:sizePublicKey], pb[:])
+	copy(res[sizePublicKey:], xb[:])
+	return res[:]
+}
+
+// SetBytes sets privKey from buf, as returned by Bytes.
+// It returns an error if x is zero or not canonical, or if P ≠ x ⋅ G₁.
+// It returns the number of bytes read from buf.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < SizePrivateKey {
+		return 0, io.ErrShortBuffer
+	}
+	var pub bls12377.G1Affine
+	if _, err := pub.SetBytes(buf[:sizePublicKey]); err != nil {
+		return 0, err
+	}
+	var x fr.Element
+	if err := x.SetBytesCanonical(buf[sizePublicKey:SizePrivateKey]); err != nil {
+		return 0, err
+	}
+	if x.IsZero() {
+		return 0, ErrZeroKey
+	}
+	var xBig big.Int
+	x.ToBigIntRegular(&xBig)
+	var expected bls12377.G1Affine
+	expected.ScalarMultiplication(&g1GenAff, &xBig)
+	if !expected.Equal(&pub) {
+		return 0, errInconsistentKey
+	}
+	privKey.PublicKey, privKey.scalar = pub, x
+	return SizePrivateKey, nil
+}
@@ -87,6 +87,9 @@ type E6 = fptower.E6
 // 𝔽p¹²
 type E12 = fptower.E12
 
+// cofactors of G1 and G2: #E(Fp) = cofactorG1⋅r and #E'(Fp²) = cofactorG2⋅r
+var cofactorG1, cofactorG2 big.Int
+
 func init() {
 
 	bCurveCoeff.SetUint64(1)
@@ -129,6 +132,8 @@ func init() {
 	// x₀
 	xGen.SetString("11045256207009841153", 10)
 
+	cofactorG1.SetString("40665894892829807646474719258757562368", 10)
+	cofactorG2.SetString("24612959932332196205839579906571768134135922624206710918257425694576233199085164797278946087292475043118880443890928096078712781000497620919226233520129", 10)
 }
 
 // Generators return the generators of the r-torsion group, resp. in ker(pi-id), ker(Tr)
@@ -139,3 +144,50 @@ func Generators() (g1Jac G1Jac, g2Jac G2Jac, g1Aff G1Affine, g2Aff G2Affine) {
 	g2Jac = g2Gen
 	return
 }
+
+// GLVBasis returns a copy of the lattice basis used to split scalars in the GLV
+// scalar multiplication of both G1 and G2 (see ecc.SplitScalar and ecc.SplitScalarInto)
+func GLVBasis() ecc.Lattice {
+	var res ecc.Lattice
+	res.Set(&glvBasis)
+	return res
+}
+
+// GLVSplit decomposes the scalar s as s ≡ k₁ + λ⋅k₂ (mod r), where λ is the eigenvalue of
+// the GLV endomorphism ϕ of both G1 and G2, and k₁, k₂ are about half the size of r.
+// k1 and k2 hold the absolute values of k₁ and k₂, and sign1 (resp. sign2) is set when
+// k₁ (resp. k₂) is negative.
+func GLVSplit(s *big.Int) (k1, k2 fr.Element, sign1, sign2 bool) {
+	k := ecc.SplitScalar(s, &glvBasis)
+	if k[0].Sign() == -1 {
+		k[0].Neg(&k[0])
+		sign1 = true
+	}
+	if k[1].Sign() == -1 {
+		k[1].Neg(&k[1])
+		sign2 = true
+	}
+	k1.SetBigInt(&k[0])
+	k2.SetBigInt(&k[1])
+	return
+}
+
+// ScalarFieldModulus returns r, the order of G1, G2 and GT (and the modulus of fr)
+func ScalarFieldModulus() *big.Int {
+	return fr.Modulus()
+}
+
+// BaseFieldModulus returns p, the characteristic of the base field (and the modulus of fp)
+func BaseFieldModulus() *big.Int {
+	return fp.Modulus()
+}
+
+// CofactorG1 returns the cofactor h₁ of G1 in E(Fp), that is #E(Fp) = h₁⋅r
+func CofactorG1() *big.Int {
+	return new(big.Int).Set(&cofactorG1)
+}
+
+// CofactorG2 returns the cofactor h₂ of G2 in the twist E'(Fp²), that is #E'(Fp²) = h₂⋅r
+func CofactorG2() *big.Int {
+	return new(big.Int).Set(&cofactorG2)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/fft"
 )
 
 // testSRS re-used accross tests of the KZG scheme
@@ -106,6 +107,51 @@ func TestSerializationSRS(t *testing.T) {
 
 }
 
+func TestNewSRSFromSeed(t *testing.T) {
+
+	const srsSize = 16
+
+	srs1, err := NewSRSFromSeed(srsSize, []byte("seed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srs2, err := NewSRSFromSeed(srsSize, []byte("seed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(srs1, srs2) {
+		t.Fatal("SRS derived from the same seed differ")
+	}
+
+	srs3, err := NewSRSFromSeed(srsSize, []byte("another seed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srs1.G1[1].Equal(&srs3.G1[1]) {
+		t.Fatal("SRS derived from different seeds are equal")
+	}
+
+	// commit and open under the derived SRS
+	f := randomPolynomial(srsSize)
+	digest, err := Commit(f, srs1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var point fr.Element
+	point.SetRandom()
+	proof, err := Open(f, point, srs1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(&digest, &proof, point, srs2); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewSRSFromSeed(1, []byte("seed")); err != ErrMinSRSSize {
+		t.Fatal("expected ErrMinSRSSize")
+	}
+}
+
 func TestCommit(t *testing.T) {
 
 	// create a polynomial
@@ -139,6 +185,149 @@ func TestCommit(t *testing.T) {
 
 }
 
+func TestVerifyCommitment(t *testing.T) {
+
+	f := randomPolynomial(60)
+	digest, err := Commit(f, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyCommitment(digest, f, testSRS); err != nil {
+		t.Fatal(err)
+	}
+
+	// modified coefficient
+	f[3].Double(&f[3])
+	if err := VerifyCommitment(digest, f, testSRS); err != ErrVerifyCommitment {
+		t.Fatal("expected ErrVerifyCommitment")
+	}
+
+	// same polynomial, with an extra coefficient
+	f[3].Halve()
+	var one fr.Element
+	one.SetOne()
+	if err := VerifyCommitment(digest, append(f, one), testSRS); err != ErrVerifyCommitment {
+		t.Fatal("expected ErrVerifyCommitment")
+	}
+	if err := VerifyCommitment(digest, f, testSRS); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBatchCommit(t *testing.T) {
+
+	polys := make([][]fr.Element, 7)
+	for i := 0; i < len(polys); i++ {
+		polys[i] = randomPolynomial(10 + 20*i)
+	}
+
+	for _, nbTasks := range []int{0, 1, 3, 16} {
+		digests, err := BatchCommit(polys, testSRS, nbTasks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(digests) != len(polys) {
+			t.Fatal("wrong number of digests")
+		}
+		for i := 0; i < len(polys); i++ {
+			expected, err := Commit(polys[i], testSRS)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !digests[i].Equal(&expected) {
+				t.Fatal("BatchCommit and Commit differ")
+			}
+		}
+	}
+
+	// a polynomial too large for the SRS
+	polys = append(polys, randomPolynomial(len(testSRS.G1)+1))
+	if _, err := BatchCommit(polys, testSRS, 0); err != ErrInvalidPolynomialSize {
+		t.Fatal("expected ErrInvalidPolynomialSize")
+	}
+}
+
+func TestCommitLagrange(t *testing.T) {
+
+	for _, size := range []uint64{1, 2, 16} {
+		srsLagrange, err := testSRS.ToLagrange(size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if uint64(len(srsLagrange.G1)) != size {
+			t.Fatal("wrong size of the Lagrange SRS")
+		}
+
+		// evaluations of a random polynomial on the domain
+		evals := randomPolynomial(int(size))
+		digest, err := CommitLagrange(evals, srsLagrange)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		coeffs := make([]fr.Element, size)
+		copy(coeffs, evals)
+		fft.NewDomain(size).LagrangeToMonomial(coeffs)
+		expected, err := Commit(coeffs, testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !digest.Equal(&expected) {
+			t.Fatalf("size %d: CommitLagrange and Commit differ", size)
+		}
+
+		if _, err := CommitLagrange(evals[:size-1], srsLagrange); err != ErrInvalidPolynomialSize {
+			t.Fatal("expected ErrInvalidPolynomialSize")
+		}
+
+		// batch
+		digests, err := BatchCommitLagrange([][]fr.Element{evals, randomPolynomial(int(size))}, srsLagrange, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(digests) != 2 || !digests[0].Equal(&expected) {
+			t.Fatalf("size %d: BatchCommitLagrange and Commit differ", size)
+		}
+		if _, err := BatchCommitLagrange([][]fr.Element{evals, evals[:size-1]}, srsLagrange, 0); err != ErrInvalidPolynomialSize {
+			t.Fatal("expected ErrInvalidPolynomialSize")
+		}
+	}
+
+	// domain larger than the SRS
+	if _, err := testSRS.ToLagrange(uint64(2 * len(testSRS.G1))); err != ErrInvalidPolynomialSize {
+		t.Fatal("expected ErrInvalidPolynomialSize")
+	}
+}
+
+func TestInvalidPolynomialSize(t *testing.T) {
+
+	tooLarge := randomPolynomial(len(testSRS.G1) + 1)
+	var point fr.Element
+	point.SetRandom()
+
+	for _, p := range [][]fr.Element{tooLarge, {}} {
+		if _, err := Commit(p, testSRS); err != ErrInvalidPolynomialSize {
+			t.Fatal("Commit: expected ErrInvalidPolynomialSize")
+		}
+		if _, err := Open(p, point, testSRS); err != ErrInvalidPolynomialSize {
+			t.Fatal("Open: expected ErrInvalidPolynomialSize")
+		}
+		if _, err := BatchCommit([][]fr.Element{randomPolynomial(10), p}, testSRS, 0); err != ErrInvalidPolynomialSize {
+			t.Fatal("BatchCommit: expected ErrInvalidPolynomialSize")
+		}
+	}
+
+	// the largest polynomial supported by the SRS
+	maxSize := randomPolynomial(len(testSRS.G1))
+	if _, err := Commit(maxSize, testSRS); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(maxSize, point, testSRS); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestVerifySinglePoint(t *testing.T) {
 
 	// create a polynomial
@@ -190,6 +379,132 @@ func TestVerifySinglePoint(t *testing.T) {
 	}
 }
 
+func TestVerifySinglePointG2(t *testing.T) {
+
+	srsG2, err := NewSRSG2(64, new(big.Int).SetInt64(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// create a polynomial
+	f := randomPolynomial(60)
+
+	// commit the polynomial
+	digest, err := CommitG2(f, srsG2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compute opening proof at a random point
+	var point fr.Element
+	point.SetString("4321")
+	proof, err := OpenG2(f, point, srsG2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// verify the claimed valued
+	expected := eval(f, point)
+	if !proof.ClaimedValue.Equal(&expected) {
+		t.Fatal("inconsistent claimed value")
+	}
+
+	// verify correct proof
+	err = VerifyG2(&digest, &proof, point, srsG2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	{
+		// verify wrong proof
+		proof.ClaimedValue.Double(&proof.ClaimedValue)
+		err = VerifyG2(&digest, &proof, point, srsG2)
+		if err == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+	}
+}
+
+func TestCommitG1G2Interop(t *testing.T) {
+
+	// same toxic waste as testSRS
+	srsG2, err := NewSRSG2(64, new(big.Int).SetInt64(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !srsG2.G1[1].Equal(&testSRS.G1[1]) || !srsG2.G2[1].Equal(&testSRS.G2[1]) {
+		t.Fatal("SRS in G₁ and G₂ don't share the same α")
+	}
+
+	f := randomPolynomial(60)
+	digestG1, err := Commit(f, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digestG2, err := CommitG2(f, srsG2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// e([f(α)]G₁, G₂) == e(G₁, [f(α)]G₂)
+	var negDigestG1 bls12378.G1Affine
+	negDigestG1.Neg(&digestG1)
+	check, err := bls12378.PairingCheck(
+		[]bls12378.G1Affine{negDigestG1, srsG2.G1[0]},
+		[]bls12378.G2Affine{testSRS.G2[0], digestG2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !check {
+		t.Fatal("commitments in G₁ and G₂ of the same polynomial should match")
+	}
+
+	// the opening proofs in G₁ and G₂ claim the same value, and the quotients match as well
+	var point fr.Element
+	point.SetRandom()
+	proofG1, err := Open(f, point, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proofG2, err := OpenG2(f, point, srsG2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proofG1.ClaimedValue.Equal(&proofG2.ClaimedValue) {
+		t.Fatal("opening proofs in G₁ and G₂ should claim the same value")
+	}
+	negDigestG1.Neg(&proofG1.H)
+	check, err = bls12378.PairingCheck(
+		[]bls12378.G1Affine{negDigestG1, srsG2.G1[0]},
+		[]bls12378.G2Affine{testSRS.G2[0], proofG2.H},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !check {
+		t.Fatal("quotients in G₁ and G₂ of the same polynomial should match")
+	}
+
+	// committing a different polynomial in G₂ breaks the relation
+	f[0].Double(&f[0])
+	digestG2, err = CommitG2(f, srsG2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	negDigestG1.Neg(&digestG1)
+	check, err = bls12378.PairingCheck(
+		[]bls12378.G1Affine{negDigestG1, srsG2.G1[0]},
+		[]bls12378.G2Affine{testSRS.G2[0], digestG2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if check {
+		t.Fatal("commitments of different polynomials should not match")
+	}
+}
+
 func TestBatchVerifySinglePoint(t *testing.T) {
 
 	size := 40
@@ -324,6 +639,143 @@ func TestBatchVerifyMultiPoints(t *testing.T) {
 
 }
 
+func TestBatchVerifyProofsSinglePoint(t *testing.T) {
+
+	const nbPolys = 8
+
+	// create and commit the polynomials
+	f := make([][]fr.Element, nbPolys)
+	digests := make([]Digest, nbPolys)
+	for i := 0; i < nbPolys; i++ {
+		f[i] = randomPolynomial(40)
+		digests[i], _ = Commit(f[i], testSRS)
+	}
+
+	// open each polynomial individually at the same point
+	var point fr.Element
+	point.SetRandom()
+	proofs := make([]OpeningProof, nbPolys)
+	evals := make([]fr.Element, nbPolys)
+	for i := 0; i < nbPolys; i++ {
+		var err error
+		proofs[i], err = Open(f[i], point, testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		evals[i] = proofs[i].ClaimedValue
+	}
+
+	// pick a hash function
+	hf := sha256.New()
+
+	// batch verify correct proofs
+	err := BatchVerifyProofsSinglePoint(digests, point, evals, proofs, hf, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	{
+		// batch verify with a single corrupted evaluation
+		var one fr.Element
+		one.SetOne()
+		evals[3].Add(&evals[3], &one)
+		err = BatchVerifyProofsSinglePoint(digests, point, evals, proofs, hf, testSRS)
+		if err == nil {
+			t.Fatal("verifying a corrupted evaluation should fail")
+		}
+		evals[3].Sub(&evals[3], &one)
+	}
+	{
+		// batch verify with swapped quotients
+		proofs[0].H, proofs[1].H = proofs[1].H, proofs[0].H
+		err = BatchVerifyProofsSinglePoint(digests, point, evals, proofs, hf, testSRS)
+		if err == nil {
+			t.Fatal("verifying swapped quotients should fail")
+		}
+		proofs[0].H, proofs[1].H = proofs[1].H, proofs[0].H
+	}
+	{
+		// batch verify at a different point
+		var wrongPoint fr.Element
+		wrongPoint.SetRandom()
+		err = BatchVerifyProofsSinglePoint(digests, wrongPoint, evals, proofs, hf, testSRS)
+		if err == nil {
+			t.Fatal("verifying at the wrong point should fail")
+		}
+	}
+
+	// inconsistent sizes
+	err = BatchVerifyProofsSinglePoint(digests, point, evals[1:], proofs, hf, testSRS)
+	if err != ErrInvalidNbDigests {
+		t.Fatal("expected ErrInvalidNbDigests")
+	}
+
+}
+
+func TestBatchVerifyMultiPoint(t *testing.T) {
+
+	const nbItems = 5
+
+	// commit polynomials of different sizes and open each of them at its own point
+	items := make([]BatchItem, nbItems)
+	for i := 0; i < nbItems; i++ {
+		f := randomPolynomial(10 + 7*i)
+		var err error
+		items[i].Digest, err = Commit(f, testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		items[i].Point.SetRandom()
+		items[i].Proof, err = Open(f, items[i].Point, testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		items[i].Eval = items[i].Proof.ClaimedValue
+	}
+
+	// pick a hash function
+	hf := sha256.New()
+
+	// batch verify correct openings
+	if err := BatchVerifyMultiPoint(items, testSRS, hf); err != nil {
+		t.Fatal(err)
+	}
+
+	// a single corrupted item fails the whole batch
+	var one fr.Element
+	one.SetOne()
+	for i := 0; i < nbItems; i++ {
+		corrupted := make([]BatchItem, nbItems)
+		copy(corrupted, items)
+		switch i % 3 {
+		case 0:
+			corrupted[i].Eval.Add(&corrupted[i].Eval, &one)
+		case 1:
+			corrupted[i].Point.Add(&corrupted[i].Point, &one)
+		case 2:
+			corrupted[i].Proof.H.Add(&corrupted[i].Proof.H, &testSRS.G1[0])
+		}
+		if err := BatchVerifyMultiPoint(corrupted, testSRS, hf); err != ErrVerifyOpeningProof {
+			t.Fatalf("verifying a batch with corrupted item %d should fail", i)
+		}
+	}
+
+	{
+		// swapped quotients
+		corrupted := make([]BatchItem, nbItems)
+		copy(corrupted, items)
+		corrupted[0].Proof.H, corrupted[1].Proof.H = corrupted[1].Proof.H, corrupted[0].Proof.H
+		if err := BatchVerifyMultiPoint(corrupted, testSRS, hf); err == nil {
+			t.Fatal("verifying swapped quotients should fail")
+		}
+	}
+
+	// empty batch
+	if err := BatchVerifyMultiPoint(nil, testSRS, hf); err != ErrInvalidNbDigests {
+		t.Fatal("expected ErrInvalidNbDigests")
+	}
+}
+
 const benchSize = 1 << 16
 
 func BenchmarkKZGCommit(b *testing.B) {
@@ -340,6 +792,31 @@ func BenchmarkKZGCommit(b *testing.B) {
 	}
 }
 
+func BenchmarkKZGBatchCommit(b *testing.B) {
+	const nbPolys = 8
+	benchSRS, err := NewSRS(ecc.NextPowerOfTwo(benchSize/nbPolys), new(big.Int).SetInt64(42))
+	if err != nil {
+		b.Fatal(err)
+	}
+	polys := make([][]fr.Element, nbPolys)
+	for i := 0; i < nbPolys; i++ {
+		polys[i] = randomPolynomial(benchSize / nbPolys)
+	}
+
+	b.Run("Commit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < nbPolys; j++ {
+				_, _ = Commit(polys[j], benchSRS)
+			}
+		}
+	})
+	b.Run("BatchCommit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = BatchCommit(polys, benchSRS, 0)
+		}
+	})
+}
+
 func BenchmarkDivideByXMinusA(b *testing.B) {
 	const pSize = 1 << 22
 
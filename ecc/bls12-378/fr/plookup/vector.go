@@ -26,6 +26,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/fft"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/kzg"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/polynomial"
 	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
 )
 
@@ -400,33 +401,20 @@ func ProveLookupVector(srs *kzg.SRS, f, t Table) (ProofLookupVector, error) {
 
 	// resize f and t
 	// note: the last element of lf does not matter
-	lf := make([]fr.Element, sizeDomainSmall)
-	lt := make([]fr.Element, sizeDomainSmall)
+	lf := polynomial.PadWithLast(f, sizeDomainSmall)
+	lt := polynomial.PadWithLast(t, sizeDomainSmall)
 	cf := make([]fr.Element, sizeDomainSmall)
 	ct := make([]fr.Element, sizeDomainSmall)
-	copy(lt, t)
-	copy(lf, f)
-	for i := len(f); i < sizeDomainSmall; i++ {
-		lf[i] = f[len(f)-1]
-	}
-	for i := len(t); i < sizeDomainSmall; i++ {
-		lt[i] = t[len(t)-1]
-	}
 	sort.Sort(Table(lt))
 	copy(ct, lt)
 	copy(cf, lf)
-	domainSmall.FFTInverse(ct, fft.DIF)
-	domainSmall.FFTInverse(cf, fft.DIF)
-	fft.BitReverse(ct)
-	fft.BitReverse(cf)
-	proof.t, err = kzg.Commit(ct, srs)
-	if err != nil {
-		return proof, err
-	}
-	proof.f, err = kzg.Commit(cf, srs)
+	domainSmall.LagrangeToMonomial(ct)
+	domainSmall.LagrangeToMonomial(cf)
+	digests, err := kzg.BatchCommit([][]fr.Element{ct, cf}, srs, 0)
 	if err != nil {
 		return proof, err
 	}
+	proof.t, proof.f = digests[0], digests[1]
 
 	// write f sorted by t
 	lfSortedByt := make(Table, 2*domainSmall.Cardinality-1)
@@ -444,19 +432,14 @@ func ProveLookupVector(srs *kzg.SRS, f, t Table) (ProofLookupVector, error) {
 
 	copy(ch1, lfSortedByt[:sizeDomainSmall])
 	copy(ch2, lfSortedByt[sizeDomainSmall-1:])
-	domainSmall.FFTInverse(ch1, fft.DIF)
-	domainSmall.FFTInverse(ch2, fft.DIF)
-	fft.BitReverse(ch1)
-	fft.BitReverse(ch2)
+	domainSmall.LagrangeToMonomial(ch1)
+	domainSmall.LagrangeToMonomial(ch2)
 
-	proof.h1, err = kzg.Commit(ch1, srs)
-	if err != nil {
-		return proof, err
-	}
-	proof.h2, err = kzg.Commit(ch2, srs)
+	digests, err = kzg.BatchCommit([][]fr.Element{ch1, ch2}, srs, 0)
 	if err != nil {
 		return proof, err
 	}
+	proof.h1, proof.h2 = digests[0], digests[1]
 
 	// derive beta, gamma
 	beta, err := deriveRandomness(&fs, "beta", &proof.t, &proof.f, &proof.h1, &proof.h2)
@@ -472,8 +455,7 @@ func ProveLookupVector(srs *kzg.SRS, f, t Table) (ProofLookupVector, error) {
 	lz := evaluateAccumulationPolynomial(lf, lt, lh1, lh2, beta, gamma)
 	cz := make([]fr.Element, len(lz))
 	copy(cz, lz)
-	domainSmall.FFTInverse(cz, fft.DIF)
-	fft.BitReverse(cz)
+	domainSmall.LagrangeToMonomial(cz)
 	proof.z, err = kzg.Commit(cz, srs)
 	if err != nil {
 		return proof, err
@@ -18,6 +18,7 @@ package fptower
 
 import (
 	"crypto/rand"
+	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fp"
@@ -400,6 +401,28 @@ func TestE2Ops(t *testing.T) {
 
 }
 
+func TestE2ExpNegative(t *testing.T) {
+	t.Parallel()
+
+	for _, k := range []*big.Int{
+		big.NewInt(1),
+		big.NewInt(2),
+		big.NewInt(12345),
+		new(big.Int).Lsh(big.NewInt(1), 200),
+		fp.Modulus(),
+	} {
+		var x, expected, res E2
+		x.SetRandom()
+
+		// x⁻ᵏ == (xᵏ)⁻¹
+		expected.Exp(x, k).Inverse(&expected)
+		res.Exp(x, new(big.Int).Neg(k))
+		if !res.Equal(&expected) {
+			t.Fatalf("Exp(x, -%s) should be Inverse(Exp(x, %s))", k.String(), k.String())
+		}
+	}
+}
+
 // ------------------------------------------------------------
 // benches
 
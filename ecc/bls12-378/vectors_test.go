@@ -0,0 +1,265 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12378
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+)
+
+// vectorsFile holds the test vectors of bls12-378, written by TestGenVectors and checked by TestVectors.
+//
+// They are meant for cross-implementation conformance: scalars and points are encoded as in this
+// package (scalars in base 10, points in the compressed and uncompressed formats of marshal.go, GT
+// elements as GT.Bytes), all in hexadecimal.
+var vectorsFile = filepath.Join("testdata", "vectors.json")
+
+var updateVectors = flag.Bool("update-vectors", false, "regenerate testdata/vectors.json (go test -run GenVectors -update-vectors)")
+
+type testVectors struct {
+	Curve     string                `json:"curve"`
+	ScalarMul []scalarMulTestVector `json:"scalarMul"`
+	Pairing   []pairingTestVector   `json:"pairing"`
+}
+
+// scalarMulTestVector holds s ⋅ G₁ and s ⋅ G₂, where G₁, G₂ are the generators returned by Generators()
+type scalarMulTestVector struct {
+	Scalar         string `json:"scalar"`
+	G1Compressed   string `json:"g1Compressed"`
+	G1Uncompressed string `json:"g1Uncompressed"`
+	G2Compressed   string `json:"g2Compressed"`
+	G2Uncompressed string `json:"g2Uncompressed"`
+}
+
+// pairingTestVector holds e(P, Q), with P and Q compressed
+type pairingTestVector struct {
+	G1 string `json:"g1"`
+	G2 string `json:"g2"`
+	GT string `json:"gt"`
+}
+
+// vectorScalar returns the i-th pseudo-random scalar of the test vectors, SHA256("gnark-crypto test vector" ‖ i) mod r
+func vectorScalar(i uint64) big.Int {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], i)
+	h := sha256.New()
+	h.Write([]byte("gnark-crypto test vector"))
+	h.Write(buf[:])
+	var s big.Int
+	s.SetBytes(h.Sum(nil)).Mod(&s, fr.Modulus())
+	return s
+}
+
+// computeTestVectors returns the test vectors of bls12-378; the output only depends on the behavior of the package
+func computeTestVectors() (testVectors, error) {
+	v := testVectors{Curve: "bls12-378"}
+
+	var rMinusOne big.Int
+	rMinusOne.Sub(fr.Modulus(), big.NewInt(1))
+	scalars := []big.Int{*big.NewInt(0), *big.NewInt(1), *big.NewInt(2), rMinusOne}
+	for i := uint64(0); i < 4; i++ {
+		scalars = append(scalars, vectorScalar(i))
+	}
+
+	for i := range scalars {
+		var p1 G1Affine
+		var p2 G2Affine
+		p1.ScalarMultiplication(&g1GenAff, &scalars[i])
+		p2.ScalarMultiplication(&g2GenAff, &scalars[i])
+		raw1, raw2 := p1.RawBytes(), p2.RawBytes()
+		v.ScalarMul = append(v.ScalarMul, scalarMulTestVector{
+			Scalar:         scalars[i].String(),
+			G1Compressed:   p1.EncodeHex(),
+			G1Uncompressed: hex.EncodeToString(raw1[:]),
+			G2Compressed:   p2.EncodeHex(),
+			G2Uncompressed: hex.EncodeToString(raw2[:]),
+		})
+	}
+
+	// e(∞, G₂), e(G₁, G₂) and e(a ⋅ G₁, b ⋅ G₂) for pseudo-random a, b
+	for i := uint64(0); i < 3; i++ {
+		var p1 G1Affine
+		var p2 G2Affine
+		switch i {
+		case 0:
+			p2.Set(&g2GenAff)
+		case 1:
+			p1.Set(&g1GenAff)
+			p2.Set(&g2GenAff)
+		default:
+			a, b := vectorScalar(2*i), vectorScalar(2*i+1)
+			p1.ScalarMultiplication(&g1GenAff, &a)
+			p2.ScalarMultiplication(&g2GenAff, &b)
+		}
+		gt, err := Pair([]G1Affine{p1}, []G2Affine{p2})
+		if err != nil {
+			return v, err
+		}
+		b := gt.Bytes()
+		v.Pairing = append(v.Pairing, pairingTestVector{
+			G1: p1.EncodeHex(),
+			G2: p2.EncodeHex(),
+			GT: hex.EncodeToString(b[:]),
+		})
+	}
+
+	return v, nil
+}
+
+func marshalTestVectors(v testVectors) ([]byte, error) {
+	b, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+func TestGenVectors(t *testing.T) {
+	if !*updateVectors {
+		t.Skip("run with -update-vectors to regenerate " + vectorsFile)
+	}
+	v, err := computeTestVectors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := marshalTestVectors(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(vectorsFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(vectorsFile, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVectors(t *testing.T) {
+	t.Parallel()
+
+	b, err := os.ReadFile(vectorsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v testVectors
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Curve != "bls12-378" {
+		t.Fatalf("vectors of %s, expected bls12-378", v.Curve)
+	}
+
+	for i, vec := range v.ScalarMul {
+		var s big.Int
+		if _, ok := s.SetString(vec.Scalar, 10); !ok {
+			t.Fatalf("scalarMul[%d]: invalid scalar", i)
+		}
+
+		var p1, q1 G1Affine
+		p1.ScalarMultiplication(&g1GenAff, &s)
+		if err := q1.DecodeHex(vec.G1Compressed); err != nil {
+			t.Fatalf("scalarMul[%d]: %v", i, err)
+		}
+		if !q1.Equal(&p1) {
+			t.Fatalf("scalarMul[%d]: wrong G1 point", i)
+		}
+		raw1, err := hex.DecodeString(vec.G1Uncompressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := q1.SetBytes(raw1); err != nil {
+			t.Fatalf("scalarMul[%d]: %v", i, err)
+		}
+		if !q1.Equal(&p1) {
+			t.Fatalf("scalarMul[%d]: wrong uncompressed G1 point", i)
+		}
+		if b := p1.RawBytes(); !bytes.Equal(b[:], raw1) {
+			t.Fatalf("scalarMul[%d]: wrong uncompressed G1 encoding", i)
+		}
+
+		var p2, q2 G2Affine
+		p2.ScalarMultiplication(&g2GenAff, &s)
+		if err := q2.DecodeHex(vec.G2Compressed); err != nil {
+			t.Fatalf("scalarMul[%d]: %v", i, err)
+		}
+		if !q2.Equal(&p2) {
+			t.Fatalf("scalarMul[%d]: wrong G2 point", i)
+		}
+		raw2, err := hex.DecodeString(vec.G2Uncompressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := q2.SetBytes(raw2); err != nil {
+			t.Fatalf("scalarMul[%d]: %v", i, err)
+		}
+		if !q2.Equal(&p2) {
+			t.Fatalf("scalarMul[%d]: wrong uncompressed G2 point", i)
+		}
+		if b := p2.RawBytes(); !bytes.Equal(b[:], raw2) {
+			t.Fatalf("scalarMul[%d]: wrong uncompressed G2 encoding", i)
+		}
+	}
+
+	for i, vec := range v.Pairing {
+		var p1 G1Affine
+		var p2 G2Affine
+		if err := p1.DecodeHex(vec.G1); err != nil {
+			t.Fatalf("pairing[%d]: %v", i, err)
+		}
+		if err := p2.DecodeHex(vec.G2); err != nil {
+			t.Fatalf("pairing[%d]: %v", i, err)
+		}
+		gt, err := Pair([]G1Affine{p1}, []G2Affine{p2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var expected GT
+		eb, err := hex.DecodeString(vec.GT)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := expected.SetBytes(eb); err != nil {
+			t.Fatalf("pairing[%d]: %v", i, err)
+		}
+		if !gt.Equal(&expected) {
+			t.Fatalf("pairing[%d]: wrong pairing", i)
+		}
+	}
+
+	// the vectors are stable: recomputing them gives the same file
+	computed, err := computeTestVectors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb, err := marshalTestVectors(computed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(cb, b) {
+		t.Fatal(vectorsFile + " is out of date, run go test -run GenVectors -update-vectors")
+	}
+}
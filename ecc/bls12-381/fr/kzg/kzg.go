@@ -17,15 +17,20 @@
 package kzg
 
 import (
+	"crypto/sha256"
 	"errors"
 	"hash"
 	"math/big"
+	"math/bits"
+	"runtime"
 	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
 	"github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
 var (
@@ -34,6 +39,7 @@ var (
 	ErrVerifyOpeningProof            = errors.New("can't verify opening proof")
 	ErrVerifyBatchOpeningSinglePoint = errors.New("can't verify batch opening proof at single point")
 	ErrMinSRSSize                    = errors.New("minimum srs size is 2")
+	ErrVerifyCommitment              = errors.New("the polynomial doesn't match the commitment")
 )
 
 // Digest commitment of a polynomial.
@@ -93,6 +99,23 @@ func NewSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
 	return &srs, nil
 }
 
+// NewSRSFromSeed returns a new SRS whose toxic waste α is deterministically derived from seed.
+//
+// This is INSECURE: anyone knowing the seed knows α and can forge opening proofs.
+// It is meant for tests and examples which need a reproducible SRS.
+func NewSRSFromSeed(size uint64, seed []byte) (*SRS, error) {
+	h := sha256.New()
+	h.Write([]byte("gnark-crypto/kzg/insecure-srs"))
+	h.Write(seed)
+
+	var alpha fr.Element
+	alpha.SetBytes(h.Sum(nil))
+
+	var bAlpha big.Int
+	alpha.ToBigIntRegular(&bAlpha)
+	return NewSRS(size, &bAlpha)
+}
+
 // OpeningProof KZG proof for opening at a single point.
 //
 // implements io.ReaderFrom and io.WriterTo
@@ -136,6 +159,179 @@ func Commit(p []fr.Element, srs *SRS, nbTasks ...int) (Digest, error) {
 	return res, nil
 }
 
+// VerifyCommitment checks that digest is the commitment to poly, i.e. that Commit(poly, srs) == digest.
+//
+// This is meant for commitments that are later fully revealed.
+func VerifyCommitment(digest Digest, poly []fr.Element, srs *SRS) error {
+	expected, err := Commit(poly, srs)
+	if err != nil {
+		return err
+	}
+	if !expected.Equal(&digest) {
+		return ErrVerifyCommitment
+	}
+	return nil
+}
+
+// BatchCommit commits to each polynomial in polys, see Commit.
+//
+// The polynomials are committed in parallel on nbTasks go routines (runtime.NumCPU() if nbTasks <= 0),
+// which share the available CPUs for their multi exponentiations.
+func BatchCommit(polys [][]fr.Element, srs *SRS, nbTasks int) ([]Digest, error) {
+
+	for i := 0; i < len(polys); i++ {
+		if len(polys[i]) == 0 || len(polys[i]) > len(srs.G1) {
+			return nil, ErrInvalidPolynomialSize
+		}
+	}
+
+	return batchCommit(len(polys), nbTasks, func(i, msmTasks int) (Digest, error) {
+		return Commit(polys[i], srs, msmTasks)
+	})
+}
+
+// batchCommit calls commit(i, msmTasks) for i < n in parallel on nbTasks go routines
+// (runtime.NumCPU() if nbTasks <= 0), msmTasks being their share of the available CPUs.
+func batchCommit(n, nbTasks int, commit func(i, msmTasks int) (Digest, error)) ([]Digest, error) {
+
+	res := make([]Digest, n)
+	if n == 0 {
+		return res, nil
+	}
+
+	if nbTasks <= 0 {
+		nbTasks = runtime.NumCPU()
+	}
+	if nbTasks > n {
+		nbTasks = n
+	}
+	msmTasks := runtime.NumCPU() / nbTasks
+	if msmTasks < 1 {
+		msmTasks = 1
+	}
+
+	var err error
+	var errLock sync.Mutex
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			d, _err := commit(i, msmTasks)
+			if _err != nil {
+				errLock.Lock()
+				err = _err
+				errLock.Unlock()
+				return
+			}
+			res[i] = d
+		}
+	}, nbTasks)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SRSLagrange stores the G1 part of an SRS in the Lagrange basis of a domain of size n,
+// that is G1[i] = [Lᵢ(α)]G₁ where Lᵢ is the i-th Lagrange polynomial on the n-th roots of unity.
+//
+// It commits to polynomials given by their evaluations on the domain, without
+// converting them to the monomial basis first.
+type SRSLagrange struct {
+	G1 []bls12381.G1Affine
+}
+
+// ToLagrange returns the Lagrange form of srs on the domain of size the smallest power of 2
+// larger than or equal to size. srs must have at least that many G1 points.
+func (srs *SRS) ToLagrange(size uint64) (*SRSLagrange, error) {
+	d := fft.NewDomain(size)
+	n := int(d.Cardinality)
+	if n > len(srs.G1) {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	// [Lᵢ(α)]G₁ = 1/n ∑ⱼ ω⁻ⁱʲ [αʲ]G₁, i.e. the inverse DFT of the first n points of srs
+	p := make([]bls12381.G1Jac, n)
+	for i := 0; i < n; i++ {
+		p[i].FromAffine(&srs.G1[i])
+	}
+	dftG1(p, d.GeneratorInv)
+	var nInv big.Int
+	d.CardinalityInv.ToBigIntRegular(&nInv)
+	for i := 0; i < n; i++ {
+		p[i].ScalarMultiplication(&p[i], &nInv)
+	}
+
+	return &SRSLagrange{G1: bls12381.BatchJacobianToAffineG1(p)}, nil
+}
+
+// dftG1 sets p to its discrete Fourier transform p[i] = ∑ⱼ ωⁱʲ p[j], where ω is a root of
+// unity of order len(p), a power of 2. Inputs and outputs are in natural order.
+func dftG1(p []bls12381.G1Jac, omega fr.Element) {
+	n := len(p)
+	nn := uint64(64 - bits.TrailingZeros64(uint64(n)))
+	for i := 0; i < n; i++ {
+		ir := int(bits.Reverse64(uint64(i)) >> nn)
+		if i < ir {
+			p[i], p[ir] = p[ir], p[i]
+		}
+	}
+
+	var t bls12381.G1Jac
+	var wm, w fr.Element
+	var bw big.Int
+	for m := 2; m <= n; m <<= 1 {
+		// wm = ω^(n/m) is a root of unity of order m
+		wm.Exp(omega, big.NewInt(int64(n/m)))
+		w.SetOne()
+		for k := 0; k < m/2; k++ {
+			w.ToBigIntRegular(&bw)
+			for j := k; j < n; j += m {
+				t.ScalarMultiplication(&p[j+m/2], &bw)
+				p[j+m/2].Set(&p[j]).SubAssign(&t)
+				p[j].AddAssign(&t)
+			}
+			w.Mul(&w, &wm)
+		}
+	}
+}
+
+// CommitLagrange commits to a polynomial given by its evaluations on the domain of srs,
+// in natural order and Montgomery form. The digest is the same as Commit on the
+// coefficients of the polynomial, with the matching monomial SRS.
+func CommitLagrange(p []fr.Element, srs *SRSLagrange, nbTasks ...int) (Digest, error) {
+
+	if len(p) != len(srs.G1) {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+
+	var res bls12381.G1Affine
+
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	if _, err := res.MultiExp(srs.G1, p, config); err != nil {
+		return Digest{}, err
+	}
+
+	return res, nil
+}
+
+// BatchCommitLagrange commits to each polynomial in polys, given by its evaluations on the
+// domain of srs, see CommitLagrange and BatchCommit.
+func BatchCommitLagrange(polys [][]fr.Element, srs *SRSLagrange, nbTasks int) ([]Digest, error) {
+
+	for i := 0; i < len(polys); i++ {
+		if len(polys[i]) != len(srs.G1) {
+			return nil, ErrInvalidPolynomialSize
+		}
+	}
+
+	return batchCommit(len(polys), nbTasks, func(i, msmTasks int) (Digest, error) {
+		return CommitLagrange(polys[i], srs, msmTasks)
+	})
+}
+
 // Open computes an opening proof of polynomial p at given point.
 // fft.Domain Cardinality must be larger than p.Degree()
 func Open(p []fr.Element, point fr.Element, srs *SRS) (OpeningProof, error) {
@@ -215,6 +411,161 @@ func Verify(commitment *Digest, proof *OpeningProof, point fr.Element, srs *SRS)
 	return nil
 }
 
+// DigestG2 commitment of a polynomial in G₂.
+type DigestG2 = bls12381.G2Affine
+
+// SRSG2 stores the result of the MPC for commitments in G₂, the roles of G₁ and G₂ being swapped
+// with respect to SRS.
+type SRSG2 struct {
+	G2 []bls12381.G2Affine  // [G₂ [α]G₂ , [α²]G₂, ... ]
+	G1 [2]bls12381.G1Affine // [G₁, [α]G₁ ]
+}
+
+// OpeningProofG2 KZG proof in G₂ for opening at a single point.
+type OpeningProofG2 struct {
+	// H quotient polynomial (f - f(z))/(x-z)
+	H bls12381.G2Affine
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+}
+
+// NewSRSG2 returns a new SRSG2 using alpha as randomness source
+//
+// Commitments in G₁ (with NewSRS) and in G₂ (with NewSRSG2) of the same polynomial
+// are consistent if the same alpha is used.
+//
+// In production, a SRS generated through MPC should be used.
+func NewSRSG2(size uint64, bAlpha *big.Int) (*SRSG2, error) {
+
+	if size < 2 {
+		return nil, ErrMinSRSSize
+	}
+
+	var srs SRSG2
+	srs.G2 = make([]bls12381.G2Affine, size)
+
+	var alpha fr.Element
+	alpha.SetBigInt(bAlpha)
+
+	_, _, gen1Aff, gen2Aff := bls12381.Generators()
+	srs.G2[0] = gen2Aff
+	srs.G1[0] = gen1Aff
+	srs.G1[1].ScalarMultiplication(&gen1Aff, bAlpha)
+
+	alphas := make([]fr.Element, size-1)
+	alphas[0] = alpha
+	for i := 1; i < len(alphas); i++ {
+		alphas[i].Mul(&alphas[i-1], &alpha)
+	}
+	for i := 0; i < len(alphas); i++ {
+		alphas[i].FromMont()
+	}
+	g2s := bls12381.BatchScalarMultiplicationG2(&gen2Aff, alphas)
+	copy(srs.G2[1:], g2s)
+
+	return &srs, nil
+}
+
+// CommitG2 commits to a polynomial in G₂ using a multi exponentiation with the SRS.
+// It is assumed that the polynomial is in canonical form, in Montgomery form.
+func CommitG2(p []fr.Element, srs *SRSG2, nbTasks ...int) (DigestG2, error) {
+
+	if len(p) == 0 || len(p) > len(srs.G2) {
+		return DigestG2{}, ErrInvalidPolynomialSize
+	}
+
+	var res bls12381.G2Affine
+
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	if _, err := res.MultiExp(srs.G2[:len(p)], p, config); err != nil {
+		return DigestG2{}, err
+	}
+
+	return res, nil
+}
+
+// OpenG2 computes an opening proof in G₂ of polynomial p at given point, see Open.
+func OpenG2(p []fr.Element, point fr.Element, srs *SRSG2) (OpeningProofG2, error) {
+	if len(p) == 0 || len(p) > len(srs.G2) {
+		return OpeningProofG2{}, ErrInvalidPolynomialSize
+	}
+
+	// build the proof
+	res := OpeningProofG2{
+		ClaimedValue: eval(p, point),
+	}
+
+	// compute H
+	_p := make([]fr.Element, len(p))
+	copy(_p, p)
+	h := dividePolyByXminusA(_p, res.ClaimedValue, point)
+
+	_p = nil // h re-use this memory
+
+	// commit to H
+	hCommit, err := CommitG2(h, srs)
+	if err != nil {
+		return OpeningProofG2{}, err
+	}
+	res.H.Set(&hCommit)
+
+	return res, nil
+}
+
+// VerifyG2 verifies a KZG opening proof in G₂ at a single point
+func VerifyG2(commitment *DigestG2, proof *OpeningProofG2, point fr.Element, srs *SRSG2) error {
+
+	// [f(a)]G₂
+	var claimedValueG2Jac, genG2Jac bls12381.G2Jac
+	var claimedValueBigInt big.Int
+	proof.ClaimedValue.ToBigIntRegular(&claimedValueBigInt)
+	genG2Jac.FromAffine(&srs.G2[0])
+	claimedValueG2Jac.ScalarMultiplication(&genG2Jac, &claimedValueBigInt)
+
+	// [f(α) - f(a)]G₂
+	var fminusfaG2Jac bls12381.G2Jac
+	fminusfaG2Jac.FromAffine(commitment)
+	fminusfaG2Jac.SubAssign(&claimedValueG2Jac)
+
+	// [-H(α)]G₂
+	var negH bls12381.G2Affine
+	negH.Neg(&proof.H)
+
+	// [α-a]G₁
+	var alphaMinusaG1Jac, alphaG1Jac bls12381.G1Jac
+	var pointBigInt big.Int
+	point.ToBigIntRegular(&pointBigInt)
+	alphaG1Jac.FromAffine(&srs.G1[1])
+	alphaMinusaG1Jac.ScalarMultiplicationAffine(&srs.G1[0], &pointBigInt).
+		Neg(&alphaMinusaG1Jac).
+		AddAssign(&alphaG1Jac)
+
+	// [α-a]G₁
+	var xminusaG1Aff bls12381.G1Affine
+	xminusaG1Aff.FromJacobian(&alphaMinusaG1Jac)
+
+	// [f(α) - f(a)]G₂
+	var fminusfaG2Aff bls12381.G2Affine
+	fminusfaG2Aff.FromJacobian(&fminusfaG2Jac)
+
+	// e(G₁, [f(α) - f(a)]G₂).e([α-a]G₁, [-H(α)]G₂) ==? 1
+	check, err := bls12381.PairingCheck(
+		[]bls12381.G1Affine{srs.G1[0], xminusaG1Aff},
+		[]bls12381.G2Affine{fminusfaG2Aff, negH},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
 // BatchOpenSinglePoint creates a batch opening proof at point of a list of polynomials.
 // It's an interactive protocol, made non interactive using Fiat Shamir.
 //
@@ -361,6 +712,76 @@ func BatchVerifySinglePoint(digests []Digest, batchOpeningProof *BatchOpeningPro
 
 }
 
+// BatchVerifyProofsSinglePoint batch verifies a list of individual opening proofs,
+// all at the same point, with a single pairing check.
+//
+// The proofs are folded with the powers of a challenge γ derived using Fiat Shamir
+// from the point, the digests, the evaluations and the quotients.
+// (BatchVerifySinglePoint verifies a BatchOpeningProof, which already carries a single quotient.)
+//
+// * digests list of committed polynomials
+// * evals list of claimed evaluations at point, one for each digest (proofs[i].ClaimedValue is ignored)
+// * proofs list of opening proofs, one for each digest
+func BatchVerifyProofsSinglePoint(digests []Digest, point fr.Element, evals []fr.Element, proofs []OpeningProof, hf hash.Hash, srs *SRS) error {
+
+	nbDigests := len(digests)
+
+	// check consistancy nb proofs vs nb digests vs nb evals
+	if nbDigests == 0 || nbDigests != len(proofs) || nbDigests != len(evals) {
+		return ErrInvalidNbDigests
+	}
+
+	// derive the challenge γ, binded to the point, the commitments, the evaluations and the quotients
+	fs := fiatshamir.NewTranscript(hf, "gamma")
+	if err := fs.Bind("gamma", point.Marshal()); err != nil {
+		return err
+	}
+	for i := 0; i < nbDigests; i++ {
+		if err := fs.Bind("gamma", digests[i].Marshal()); err != nil {
+			return err
+		}
+		if err := fs.Bind("gamma", evals[i].Marshal()); err != nil {
+			return err
+		}
+		if err := fs.Bind("gamma", proofs[i].H.Marshal()); err != nil {
+			return err
+		}
+	}
+	gammaByte, err := fs.ComputeChallenge("gamma")
+	if err != nil {
+		return err
+	}
+	var gamma fr.Element
+	gamma.SetBytes(gammaByte)
+
+	// gammai = [1,γ,γ²,..,γⁿ⁻¹]
+	gammai := make([]fr.Element, nbDigests)
+	gammai[0].SetOne()
+	for i := 1; i < nbDigests; i++ {
+		gammai[i].Mul(&gammai[i-1], &gamma)
+	}
+
+	// fold the digests and the evaluations: ∑ᵢγⁱ[fᵢ(α)]G₁, ∑ᵢγⁱfᵢ(z)
+	foldedDigest, foldedEval, err := fold(digests, evals, gammai)
+	if err != nil {
+		return err
+	}
+
+	// fold the quotients: ∑ᵢγⁱ[Hᵢ(α)]G₁
+	quotients := make([]bls12381.G1Affine, nbDigests)
+	for i := 0; i < nbDigests; i++ {
+		quotients[i].Set(&proofs[i].H)
+	}
+	var foldedProof OpeningProof
+	if _, err := foldedProof.H.MultiExp(quotients, gammai, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return err
+	}
+	foldedProof.ClaimedValue.Set(&foldedEval)
+
+	// verify the folded proof against the folded digest
+	return Verify(&foldedDigest, &foldedProof, point, srs)
+}
+
 // BatchVerifyMultiPoints batch verifies a list of opening proofs at different points.
 // The purpose of the batching is to have only one pairing for verifying several proofs.
 //
@@ -457,6 +878,120 @@ func BatchVerifyMultiPoints(digests []Digest, proofs []OpeningProof, points []fr
 
 }
 
+// BatchItem is an opening of a committed polynomial at a point, as verified by BatchVerifyMultiPoint.
+type BatchItem struct {
+	// Digest commitment of the polynomial
+	Digest Digest
+
+	// Point at which the polynomial is opened
+	Point fr.Element
+
+	// Eval claimed evaluation at Point (Proof.ClaimedValue is ignored)
+	Eval fr.Element
+
+	// Proof opening proof of Digest at Point
+	Proof OpeningProof
+}
+
+// BatchVerifyMultiPoint verifies a list of openings of different commitments at different points
+// with a single pairing check.
+//
+// The openings are combined with the powers of a challenge λ derived using Fiat Shamir
+// from all the items, and the pairing check is
+// e(∑ᵢλⁱ([fᵢ(α)]G₁ - [fᵢ(zᵢ)]G₁ + zᵢ[Hᵢ(α)]G₁), G₂) ⋅ e(-∑ᵢλⁱ[Hᵢ(α)]G₁, [α]G₂) == 1.
+// Unlike BatchVerifyMultiPoints, the verification is deterministic.
+func BatchVerifyMultiPoint(items []BatchItem, srs *SRS, hf hash.Hash) error {
+
+	nbItems := len(items)
+	if nbItems == 0 {
+		return ErrInvalidNbDigests
+	}
+
+	// derive the challenge λ, binded to the commitments, the points, the evaluations and the quotients
+	fs := fiatshamir.NewTranscript(hf, "lambda")
+	for i := 0; i < nbItems; i++ {
+		if err := fs.Bind("lambda", items[i].Digest.Marshal()); err != nil {
+			return err
+		}
+		if err := fs.Bind("lambda", items[i].Point.Marshal()); err != nil {
+			return err
+		}
+		if err := fs.Bind("lambda", items[i].Eval.Marshal()); err != nil {
+			return err
+		}
+		if err := fs.Bind("lambda", items[i].Proof.H.Marshal()); err != nil {
+			return err
+		}
+	}
+	lambdaByte, err := fs.ComputeChallenge("lambda")
+	if err != nil {
+		return err
+	}
+	var lambda fr.Element
+	lambda.SetBytes(lambdaByte)
+
+	// lambdai = [1,λ,λ²,..,λⁿ⁻¹]
+	lambdai := make([]fr.Element, nbItems)
+	lambdai[0].SetOne()
+	for i := 1; i < nbItems; i++ {
+		lambdai[i].Mul(&lambdai[i-1], &lambda)
+	}
+
+	// fold the digests and the evaluations: ∑ᵢλⁱ[fᵢ(α)]G₁, ∑ᵢλⁱfᵢ(zᵢ)
+	digests := make([]Digest, nbItems)
+	evals := make([]fr.Element, nbItems)
+	quotients := make([]bls12381.G1Affine, nbItems)
+	for i := 0; i < nbItems; i++ {
+		digests[i].Set(&items[i].Digest)
+		evals[i].Set(&items[i].Eval)
+		quotients[i].Set(&items[i].Proof.H)
+	}
+	foldedDigests, foldedEvals, err := fold(digests, evals, lambdai)
+	if err != nil {
+		return err
+	}
+
+	// ∑ᵢλⁱ[fᵢ(α)]G₁ - [∑ᵢλⁱfᵢ(zᵢ)]G₁
+	var foldedEvalsCommit bls12381.G1Affine
+	var foldedEvalsBigInt big.Int
+	foldedEvals.ToBigIntRegular(&foldedEvalsBigInt)
+	foldedEvalsCommit.ScalarMultiplication(&srs.G1[0], &foldedEvalsBigInt)
+	foldedDigests.Sub(&foldedDigests, &foldedEvalsCommit)
+
+	// fold the quotients: ∑ᵢλⁱ[Hᵢ(α)]G₁
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	var foldedQuotients bls12381.G1Affine
+	if _, err := foldedQuotients.MultiExp(quotients, lambdai, config); err != nil {
+		return err
+	}
+
+	// ∑ᵢλⁱzᵢ[Hᵢ(α)]G₁
+	var foldedPointsQuotients bls12381.G1Affine
+	for i := 0; i < nbItems; i++ {
+		lambdai[i].Mul(&lambdai[i], &items[i].Point)
+	}
+	if _, err := foldedPointsQuotients.MultiExp(quotients, lambdai, config); err != nil {
+		return err
+	}
+	foldedDigests.Add(&foldedDigests, &foldedPointsQuotients)
+
+	// -∑ᵢλⁱ[Hᵢ(α)]G₁
+	foldedQuotients.Neg(&foldedQuotients)
+
+	// pairing check
+	check, err := bls12381.PairingCheck(
+		[]bls12381.G1Affine{foldedDigests, foldedQuotients},
+		[]bls12381.G2Affine{srs.G2[0], srs.G2[1]},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
 // fold folds digests and evaluations using the list of factors as random numbers.
 //
 // * digests list of digests to fold
@@ -501,6 +501,66 @@ func (z *E12) Exp(x E12, k *big.Int) *E12 {
 	return z
 }
 
+// MultiExp sets z = ∏ᵢ elems[i]^exps[i] (mod q¹²) and returns it
+// uses the bucket (Pippenger) method over c-bit windows, with cyclotomic squarings
+// elems must be in the cyclotomic subgroup (e.g. in GT)
+// returns an error if len(elems) != len(exps)
+func (z *E12) MultiExp(elems []E12, exps []fr.Element) (*E12, error) {
+	if len(elems) != len(exps) {
+		return nil, errors.New("len(elems) != len(exps)")
+	}
+
+	// window size: with n elements the cost is about fr.Bits/c ⋅ (n + 2ᶜ⁺¹) multiplications
+	c := 1
+	for len(elems) >= 1<<(c+3) && c < 16 {
+		c++
+	}
+	mask := uint64(1<<c) - 1
+
+	scalars := make([]fr.Element, len(exps))
+	for i := range exps {
+		scalars[i] = exps[i]
+		scalars[i].FromMont()
+	}
+
+	var res, runningProduct, windowProduct E12
+	buckets := make([]E12, (1<<c)-1)
+	res.SetOne()
+	for w := (fr.Bits+c-1)/c - 1; w >= 0; w-- {
+		for j := 0; j < c; j++ {
+			res.CyclotomicSquare(&res)
+		}
+
+		// buckets[d-1] = ∏ elems[i] such that the w-th digit of exps[i] is d
+		for k := range buckets {
+			buckets[k].SetOne()
+		}
+		index, shift := (w*c)/64, uint64((w*c)%64)
+		for i := range scalars {
+			digit := scalars[i][index] >> shift
+			if shift+uint64(c) > 64 && index+1 < fr.Limbs {
+				digit |= scalars[i][index+1] << (64 - shift)
+			}
+			digit &= mask
+			if digit != 0 {
+				buckets[digit-1].Mul(&buckets[digit-1], &elems[i])
+			}
+		}
+
+		// windowProduct = ∏ₖ buckets[k]^(k+1)
+		runningProduct.SetOne()
+		windowProduct.SetOne()
+		for k := len(buckets) - 1; k >= 0; k-- {
+			runningProduct.Mul(&runningProduct, &buckets[k])
+			windowProduct.Mul(&windowProduct, &runningProduct)
+		}
+		res.Mul(&res, &windowProduct)
+	}
+
+	z.Set(&res)
+	return z, nil
+}
+
 // CyclotomicExp sets z=xᵏ (mod q¹²) and returns it
 // uses 2-NAF decomposition
 // x must be in the cyclotomic subgroup
@@ -778,6 +838,34 @@ func (z *E12) SetBytes(e []byte) error {
 	return nil
 }
 
+// ToFpSlice returns the 12 fp coefficients of z, in the tower order
+//
+//	z.C0.B0.A0, z.C0.B0.A1, z.C0.B1.A0, z.C0.B1.A1, z.C0.B2.A0, z.C0.B2.A1,
+//	z.C1.B0.A0, z.C1.B0.A1, z.C1.B1.A0, z.C1.B1.A1, z.C1.B2.A0, z.C1.B2.A1
+//
+// that is, E12 = E6[w] = C0 + C1⋅w, E6 = E2[v] = B0 + B1⋅v + B2⋅v² and E2 = fp[u] = A0 + A1⋅u.
+// This ordering is stable; it is the reverse of the order of the fp chunks in Bytes().
+func (z *E12) ToFpSlice() (res [12]fp.Element) {
+	for i, c := range [2]*E6{&z.C0, &z.C1} {
+		for j, b := range [3]*E2{&c.B0, &c.B1, &c.B2} {
+			res[6*i+2*j] = b.A0
+			res[6*i+2*j+1] = b.A1
+		}
+	}
+	return
+}
+
+// FromFpSlice sets z from its 12 fp coefficients, in the order returned by ToFpSlice, and returns z
+func (z *E12) FromFpSlice(s [12]fp.Element) *E12 {
+	for i, c := range [2]*E6{&z.C0, &z.C1} {
+		for j, b := range [3]*E2{&c.B0, &c.B1, &c.B2} {
+			b.A0 = s[6*i+2*j]
+			b.A1 = s[6*i+2*j+1]
+		}
+	}
+	return z
+}
+
 // IsInSubGroup ensures GT/E12 is in correct sugroup
 func (z *E12) IsInSubGroup() bool {
 	var a, b E12
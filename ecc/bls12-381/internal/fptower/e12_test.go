@@ -17,10 +17,12 @@
 package fptower
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/prop"
 )
@@ -57,6 +59,47 @@ func TestE12Serialization(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestE12FpSlice(t *testing.T) {
+
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := GenE12()
+
+	properties.Property("[BLS12-381] FromFpSlice(ToFpSlice()) should stay constant", prop.ForAll(
+		func(a *E12) bool {
+			var b E12
+			b.FromFpSlice(a.ToFpSlice())
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[BLS12-381] ToFpSlice()[i] should be the (11-i)-th fp chunk of Bytes()", prop.ForAll(
+		func(a *E12) bool {
+			s := a.ToFpSlice()
+			buf := a.Bytes()
+			for i := 0; i < 12; i++ {
+				c := s[i].Bytes()
+				if !bytes.Equal(c[:], buf[(11-i)*fp.Bytes:(12-i)*fp.Bytes]) {
+					return false
+				}
+			}
+			return s[0].Equal(&a.C0.B0.A0) && s[5].Equal(&a.C0.B2.A1) && s[6].Equal(&a.C1.B0.A0) && s[11].Equal(&a.C1.B2.A1)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
 func TestE12ReceiverIsOperand(t *testing.T) {
 
 	parameters := gopter.DefaultTestParameters()
@@ -450,6 +493,81 @@ func TestE12Ops(t *testing.T) {
 
 }
 
+func TestE12ExpNegative(t *testing.T) {
+	t.Parallel()
+
+	for _, k := range []*big.Int{
+		big.NewInt(1),
+		big.NewInt(2),
+		big.NewInt(12345),
+		new(big.Int).Lsh(big.NewInt(1), 200),
+		fp.Modulus(),
+	} {
+		var x, expected, res E12
+		x.SetRandom()
+
+		// x⁻ᵏ == (xᵏ)⁻¹
+		expected.Exp(x, k).Inverse(&expected)
+		res.Exp(x, new(big.Int).Neg(k))
+		if !res.Equal(&expected) {
+			t.Fatalf("Exp(x, -%s) should be Inverse(Exp(x, %s))", k.String(), k.String())
+		}
+	}
+}
+
+// randomCyclotomicE12 returns n random elements of the cyclotomic subgroup
+func randomCyclotomicE12(n int) []E12 {
+	res := make([]E12, n)
+	for i := range res {
+		var a, b E12
+		a.SetRandom()
+		b.Conjugate(&a)
+		a.Inverse(&a)
+		b.Mul(&b, &a)
+		res[i].FrobeniusSquare(&b).Mul(&res[i], &b)
+	}
+	return res
+}
+
+func TestE12MultiExp(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{0, 1, 5, 40, 130} {
+		elems := randomCyclotomicE12(n)
+		exps := make([]fr.Element, n)
+		for i := range exps {
+			exps[i].SetRandom()
+		}
+		if n > 2 {
+			// zero and r-1 exponents
+			exps[0].SetZero()
+			exps[1].SetOne().Neg(&exps[1])
+		}
+
+		// ∏ᵢ Exp(elems[i], exps[i])
+		var expected, tmp E12
+		var e big.Int
+		expected.SetOne()
+		for i := range elems {
+			tmp.Exp(elems[i], exps[i].ToBigIntRegular(&e))
+			expected.Mul(&expected, &tmp)
+		}
+
+		var res E12
+		if _, err := res.MultiExp(elems, exps); err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(&expected) {
+			t.Fatalf("MultiExp of %d elements should match the product of Exp", n)
+		}
+	}
+
+	var res E12
+	if _, err := res.MultiExp(randomCyclotomicE12(2), make([]fr.Element, 3)); err == nil {
+		t.Fatal("MultiExp should fail on mismatching lengths")
+	}
+}
+
 // ------------------------------------------------------------
 // benches
 
@@ -567,3 +685,31 @@ func TestE12Div(t *testing.T) {
 
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
+
+func BenchmarkE12MultiExp(b *testing.B) {
+	const n = 64
+	elems := randomCyclotomicE12(n)
+	exps := make([]fr.Element, n)
+	for i := range exps {
+		exps[i].SetRandom()
+	}
+
+	b.Run("naive", func(b *testing.B) {
+		var res, tmp E12
+		var e big.Int
+		for j := 0; j < b.N; j++ {
+			res.SetOne()
+			for i := range elems {
+				tmp.CyclotomicExp(elems[i], exps[i].ToBigIntRegular(&e))
+				res.Mul(&res, &tmp)
+			}
+		}
+	})
+
+	b.Run("MultiExp", func(b *testing.B) {
+		var res E12
+		for j := 0; j < b.N; j++ {
+			res.MultiExp(elems, exps)
+		}
+	})
+}
@@ -137,6 +137,39 @@ func TestPairing(t *testing.T) {
 		genR1,
 		genR2,
 	))
+	properties.Property("[BLS12-381] PairOptimalAte should output e(g1, g2)^(a*b) on (a*g1, b*g2) and e(g1, g2)^(a+b) on (a*g1, g2), (g1, b*g2)", prop.ForAll(
+		func(a, b fr.Element) bool {
+
+			var gen, expectedMul, expectedAdd GT
+			var ag1 G1Affine
+			var bg2 G2Affine
+			var abigint, bbigint, ab, aPlusB big.Int
+
+			a.ToBigIntRegular(&abigint)
+			b.ToBigIntRegular(&bbigint)
+			ab.Mul(&abigint, &bbigint)
+			aPlusB.Add(&abigint, &bbigint)
+			ag1.ScalarMultiplication(&g1GenAff, &abigint)
+			bg2.ScalarMultiplication(&g2GenAff, &bbigint)
+
+			gen, _ = Pair([]G1Affine{g1GenAff}, []G2Affine{g2GenAff})
+			expectedMul.Exp(gen, &ab)
+			expectedAdd.Exp(gen, &aPlusB)
+
+			resMul, err := PairOptimalAte([]G1Affine{ag1}, []G2Affine{bg2})
+			if err != nil {
+				return false
+			}
+			resAdd, err := PairOptimalAte([]G1Affine{ag1, g1GenAff}, []G2Affine{g2GenAff, bg2})
+			if err != nil {
+				return false
+			}
+
+			return resMul.Equal(&expectedMul) && resAdd.Equal(&expectedAdd)
+		},
+		genR1,
+		genR2,
+	))
 
 	properties.Property("[BLS12-381] PairingCheck", prop.ForAll(
 		func(a, b fr.Element) bool {
@@ -154,6 +187,62 @@ func TestPairing(t *testing.T) {
 		genR2,
 	))
 
+	properties.Property("[BLS12-381] PairingEquality should match comparing both pairings", prop.ForAll(
+		func(a, b fr.Element) bool {
+
+			var abigint, bbigint, abbigint big.Int
+			a.ToBigIntRegular(&abigint)
+			b.ToBigIntRegular(&bbigint)
+			abbigint.Mul(&abigint, &bbigint)
+
+			var aG1, bG1, abG1 G1Affine
+			var bG2, abG2 G2Affine
+			aG1.ScalarMultiplication(&g1GenAff, &abigint)
+			bG1.ScalarMultiplication(&g1GenAff, &bbigint)
+			abG1.ScalarMultiplication(&g1GenAff, &abbigint)
+			abG2.ScalarMultiplication(&g2GenAff, &abbigint)
+			bG2.ScalarMultiplication(&g2GenAff, &bbigint)
+
+			equal := func(p G1Affine, q G2Affine, r G1Affine, s G2Affine) bool {
+				e1, _ := Pair([]G1Affine{p}, []G2Affine{q})
+				e2, _ := Pair([]G1Affine{r}, []G2Affine{s})
+				return e1.Equal(&e2)
+			}
+
+			// e([a]G1, [b]G2) = e([ab]G1, G2) = e(G1, [ab]G2)
+			if !PairingEquality(aG1, bG2, abG1, g2GenAff) || !equal(aG1, bG2, abG1, g2GenAff) {
+				return false
+			}
+			if !PairingEquality(aG1, bG2, g1GenAff, abG2) {
+				return false
+			}
+
+			// e([a]G1, [b]G2) ≠ e([b]G1, G2) unless a = 1 or b = 0
+			if PairingEquality(aG1, bG2, bG1, g2GenAff) != equal(aG1, bG2, bG1, g2GenAff) {
+				return false
+			}
+
+			// e([a]G1, G2) ⋅ e([b]G1, G2) = e(G1, [a+b]G2)
+			var apb fr.Element
+			var apbbigint big.Int
+			apb.Add(&a, &b).ToBigIntRegular(&apbbigint)
+			var apbG2 G2Affine
+			apbG2.ScalarMultiplication(&g2GenAff, &apbbigint)
+			ok, err := PairingProductEquality(
+				[]G1Affine{aG1, bG1}, []G2Affine{g2GenAff, g2GenAff},
+				[]G1Affine{g1GenAff}, []G2Affine{apbG2},
+			)
+			if err != nil || !ok {
+				return false
+			}
+
+			_, err = PairingProductEquality([]G1Affine{aG1}, []G2Affine{}, []G1Affine{bG1}, []G2Affine{bG2})
+			return err != nil
+		},
+		genR1,
+		genR2,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
@@ -284,9 +373,402 @@ func TestMillerLoop(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestMillerLoopLines(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 5; i++ {
+		var a, b fr.Element
+		a.SetRandom()
+		b.SetRandom()
+		var abigint, bbigint big.Int
+		a.ToBigIntRegular(&abigint)
+		b.ToBigIntRegular(&bbigint)
+
+		var P G1Affine
+		var Q G2Affine
+		P.ScalarMultiplication(&g1GenAff, &abigint)
+		Q.ScalarMultiplication(&g2GenAff, &bbigint)
+
+		lines, f := MillerLoopLines(P, Q)
+		expected, err := MillerLoop([]G1Affine{P}, []G2Affine{Q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !f.Equal(&expected) {
+			t.Fatal("MillerLoopLines and MillerLoop should output the same result")
+		}
+
+		// multiplying the lines reproduces the Miller loop output
+		var replay GT
+		replay.SetOne()
+		for j := 0; j < len(lines); j++ {
+			replay.Square(&replay).Mul(&replay, &lines[j])
+		}
+		replay.Conjugate(&replay)
+		if !replay.Equal(&expected) {
+			t.Fatal("the lines should reproduce the Miller loop output")
+		}
+	}
+
+	// infinity
+	var g1Inf G1Affine
+	lines, f := MillerLoopLines(g1Inf, g2GenAff)
+	var one GT
+	one.SetOne()
+	if len(lines) != 0 || !f.Equal(&one) {
+		t.Fatal("MillerLoopLines should output no lines and 1 on the point at infinity")
+	}
+}
+
+func TestMillerLoopSegments(t *testing.T) {
+	t.Parallel()
+
+	// pairs including a point at infinity
+	const n = 3
+	P := make([]G1Affine, n)
+	Q := make([]G2Affine, n)
+	for i := 0; i < n; i++ {
+		var a, b fr.Element
+		a.SetRandom()
+		b.SetRandom()
+		var abigint, bbigint big.Int
+		a.ToBigIntRegular(&abigint)
+		b.ToBigIntRegular(&bbigint)
+		P[i].ScalarMultiplication(&g1GenAff, &abigint)
+		Q[i].ScalarMultiplication(&g2GenAff, &bbigint)
+	}
+	P[1] = G1Affine{}
+
+	expected, err := MillerLoop(P, Q)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoints := []int{0, 1, NbMillerLoopIterations / 4, NbMillerLoopIterations / 2, NbMillerLoopIterations / 2, NbMillerLoopIterations - 1, NbMillerLoopIterations}
+	states, err := MillerLoopSegments(P, Q, checkpoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range states {
+		if states[i].Iteration != checkpoints[i] {
+			t.Fatal("the states should be at the requested checkpoints")
+		}
+
+		// resuming from a checkpoint and completing equals the full Miller loop
+		if res := states[i].Complete(); !res.Equal(&expected) {
+			t.Fatalf("completing the checkpoint at iteration %d should output the Miller loop", checkpoints[i])
+		}
+
+		// by segments of 5 iterations
+		s := states[i]
+		for s.Iteration < NbMillerLoopIterations {
+			s = s.Resume(5)
+		}
+		if res := s.Complete(); !res.Equal(&expected) {
+			t.Fatalf("resuming the checkpoint at iteration %d should output the Miller loop", checkpoints[i])
+		}
+	}
+
+	// resuming a state doesn't modify it
+	s := states[1].Resume(NbMillerLoopIterations)
+	if states[1].Iteration != checkpoints[1] || s.Iteration != NbMillerLoopIterations {
+		t.Fatal("Resume should not modify the state")
+	}
+	if res := states[1].Complete(); !res.Equal(&expected) {
+		t.Fatal("Resume should not modify the state")
+	}
+
+	// invalid checkpoints
+	if _, err := MillerLoopSegments(P, Q, []int{2, 1}); err == nil {
+		t.Fatal("decreasing checkpoints should be rejected")
+	}
+	if _, err := MillerLoopSegments(P, Q, []int{NbMillerLoopIterations + 1}); err == nil {
+		t.Fatal("checkpoints after the end of the loop should be rejected")
+	}
+}
+
+func TestMillerLoopFixedQ(t *testing.T) {
+	t.Parallel()
+
+	var a, b fr.Element
+	a.SetRandom()
+	b.SetRandom()
+	var abigint, bbigint big.Int
+	a.ToBigIntRegular(&abigint)
+	b.ToBigIntRegular(&bbigint)
+
+	// P, P' = [2]P and Q share the same precomputed lines
+	var P, PP G1Affine
+	var Q G2Affine
+	P.ScalarMultiplication(&g1GenAff, &abigint)
+	PP.Add(&P, &P)
+	Q.ScalarMultiplication(&g2GenAff, &bbigint)
+	lines := PrecomputeLines(Q)
+
+	for _, p := range [][]G1Affine{{P}, {PP}, {P, PP}, {P, {}, PP}} {
+		q := make([]G2Affine, len(p))
+		l := make([]PrecomputedLines, len(p))
+		for i := range p {
+			q[i] = Q
+			l[i] = lines
+		}
+		expected, err := MillerLoop(p, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := MillerLoopFixedQ(p, l)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(&expected) {
+			t.Fatal("MillerLoopFixedQ and MillerLoop should output the same result")
+		}
+	}
+
+	// pairs with different Q, including the point at infinity
+	var g2Inf G2Affine
+	expected, err := MillerLoop([]G1Affine{P, PP, P}, []G2Affine{Q, g2GenAff, g2Inf})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := MillerLoopFixedQ([]G1Affine{P, PP, P}, []PrecomputedLines{lines, PrecomputeLines(g2GenAff), PrecomputeLines(g2Inf)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Equal(&expected) {
+		t.Fatal("MillerLoopFixedQ and MillerLoop should output the same result")
+	}
+
+	// e(P', Q) = e(P, Q)²
+	var e, ee GT
+	res, _ = MillerLoopFixedQ([]G1Affine{P}, []PrecomputedLines{lines})
+	e = FinalExponentiation(&res)
+	res, _ = MillerLoopFixedQ([]G1Affine{PP}, []PrecomputedLines{lines})
+	ee = FinalExponentiation(&res)
+	e.Square(&e)
+	if !e.Equal(&ee) {
+		t.Fatal("e([2]P, Q) should be e(P, Q)²")
+	}
+
+	// invalid inputs sizes
+	if _, err := MillerLoopFixedQ([]G1Affine{P, PP}, []PrecomputedLines{lines}); err == nil {
+		t.Fatal("MillerLoopFixedQ should reject inputs of different sizes")
+	}
+}
+
+func TestAccumulateMillerLoop(t *testing.T) {
+	t.Parallel()
+
+	// K pairing checks ∏ e(Pᵢ, Qᵢ) = 1, each of the form e([a]P, [b]Q)⋅e(-[ab]P, Q) = 1
+	const K = 4
+	Ps := make([][]G1Affine, K)
+	Qs := make([][]G2Affine, K)
+	for k := 0; k < K; k++ {
+		var a, b, ab fr.Element
+		a.SetRandom()
+		b.SetRandom()
+		ab.Mul(&a, &b)
+		var abigint, bbigint, abbigint big.Int
+		a.ToBigIntRegular(&abigint)
+		b.ToBigIntRegular(&bbigint)
+		ab.ToBigIntRegular(&abbigint)
+
+		Ps[k] = make([]G1Affine, 2)
+		Qs[k] = make([]G2Affine, 2)
+		Ps[k][0].ScalarMultiplication(&g1GenAff, &abigint)
+		Qs[k][0].ScalarMultiplication(&g2GenAff, &bbigint)
+		Ps[k][1].ScalarMultiplication(&g1GenAff, &abbigint).Neg(&Ps[k][1])
+		Qs[k][1] = g2GenAff
+	}
+
+	accumulate := func(Ps [][]G1Affine, Qs [][]G2Affine) GT {
+		var acc GT
+		acc.SetOne()
+		for k := range Ps {
+			if _, err := AccumulateMillerLoop(&acc, Ps[k], Qs[k]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return acc
+	}
+
+	// each check holds, and so does the accumulated one
+	var P []G1Affine
+	var Q []G2Affine
+	for k := 0; k < K; k++ {
+		ok, err := PairingCheck(Ps[k], Qs[k])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("PairingCheck should hold")
+		}
+		P = append(P, Ps[k]...)
+		Q = append(Q, Qs[k]...)
+	}
+	acc := accumulate(Ps, Qs)
+	if !FinalizeAccumulator(&acc) {
+		t.Fatal("the accumulated pairing checks should hold")
+	}
+
+	// the accumulator is the Miller loop of all the pairs
+	expected, err := MillerLoop(P, Q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !acc.Equal(&expected) {
+		t.Fatal("the accumulator should be the Miller loop of all the pairs")
+	}
+
+	// a single failing check fails the accumulated one
+	Qs[2][1] = Qs[2][0]
+	if ok, _ := PairingCheck(Ps[2], Qs[2]); ok {
+		t.Fatal("PairingCheck should fail")
+	}
+	acc = accumulate(Ps, Qs)
+	if FinalizeAccumulator(&acc) {
+		t.Fatal("the accumulated pairing checks should fail")
+	}
+
+	// invalid inputs sizes leave the accumulator unchanged
+	acc.SetOne()
+	if _, err := AccumulateMillerLoop(&acc, Ps[0], Qs[0][:1]); err == nil {
+		t.Fatal("AccumulateMillerLoop should reject inputs of different sizes")
+	}
+	var one GT
+	one.SetOne()
+	if !acc.Equal(&one) {
+		t.Fatal("the accumulator should be unchanged on error")
+	}
+}
+
+func TestPairBatch(t *testing.T) {
+	t.Parallel()
+
+	const n = 5
+	P := make([]G1Affine, n)
+	Q := make([]G2Affine, n)
+	for i := 0; i < n; i++ {
+		var a, b fr.Element
+		a.SetRandom()
+		b.SetRandom()
+		var abigint, bbigint big.Int
+		a.ToBigIntRegular(&abigint)
+		b.ToBigIntRegular(&bbigint)
+		P[i].ScalarMultiplication(&g1GenAff, &abigint)
+		Q[i].ScalarMultiplication(&g2GenAff, &bbigint)
+	}
+	// a pair containing the point at infinity
+	P[3] = G1Affine{}
+
+	res, err := PairBatch(P, Q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != n {
+		t.Fatalf("PairBatch should return %d pairings, got %d", n, len(res))
+	}
+	for i := 0; i < n; i++ {
+		expected, err := Pair(P[i:i+1], Q[i:i+1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res[i].Equal(&expected) {
+			t.Fatalf("PairBatch[%d] should equal Pair on the same pair", i)
+		}
+	}
+
+	// empty batches are valid, inputs of different sizes are not
+	if res, err := PairBatch(nil, nil); err != nil || len(res) != 0 {
+		t.Fatal("PairBatch should accept an empty batch")
+	}
+	if _, err := PairBatch(P, Q[:n-1]); err == nil {
+		t.Fatal("PairBatch should reject inputs of different sizes")
+	}
+}
+
 // ------------------------------------------------------------
 // benches
 
+func TestPairingInfinity(t *testing.T) {
+	_, _, g1GenAff, g2GenAff := Generators()
+
+	var g1Inf G1Affine
+	var g2Inf G2Affine
+	g1Inf.FromJacobian(&g1Infinity)
+	g2Inf.FromJacobian(&g2Infinity)
+
+	var one GT
+	one.SetOne()
+
+	// e(O, Q), e(P, O) and e(O, O) are the identity of GT
+	for _, tc := range []struct {
+		name string
+		P    G1Affine
+		Q    G2Affine
+	}{
+		{"e(O, Q)", g1Inf, g2GenAff},
+		{"e(P, O)", g1GenAff, g2Inf},
+		{"e(O, O)", g1Inf, g2Inf},
+	} {
+		ml, err := MillerLoop([]G1Affine{tc.P}, []G2Affine{tc.Q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ml.Equal(&one) {
+			t.Fatalf("MillerLoop: %s should be 1", tc.name)
+		}
+		res, err := Pair([]G1Affine{tc.P}, []G2Affine{tc.Q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(&one) {
+			t.Fatalf("Pair: %s should be 1", tc.name)
+		}
+		ok, err := PairingCheck([]G1Affine{tc.P}, []G2Affine{tc.Q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatalf("PairingCheck: %s should be 1", tc.name)
+		}
+	}
+
+	// mixed batches: pairs containing infinity don't change the result
+	var a, b fr.Element
+	a.SetRandom()
+	b.SetRandom()
+	var abigint, bbigint big.Int
+	a.ToBigIntRegular(&abigint)
+	b.ToBigIntRegular(&bbigint)
+	var ag1 G1Affine
+	var bg2 G2Affine
+	ag1.ScalarMultiplication(&g1GenAff, &abigint)
+	bg2.ScalarMultiplication(&g2GenAff, &bbigint)
+
+	expected, err := Pair([]G1Affine{g1GenAff, ag1}, []G2Affine{bg2, g2GenAff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		P []G1Affine
+		Q []G2Affine
+	}{
+		{[]G1Affine{g1Inf, g1GenAff, ag1}, []G2Affine{bg2, bg2, g2GenAff}},
+		{[]G1Affine{g1GenAff, ag1, ag1}, []G2Affine{bg2, g2GenAff, g2Inf}},
+		{[]G1Affine{g1GenAff, g1Inf, ag1, g1Inf}, []G2Affine{bg2, g2Inf, g2GenAff, g2GenAff}},
+	} {
+		res, err := Pair(tc.P, tc.Q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(&expected) {
+			t.Fatal("pairs containing the point at infinity should be skipped")
+		}
+	}
+}
+
 func BenchmarkPairing(b *testing.B) {
 
 	var g1GenAff G1Affine
@@ -314,6 +796,33 @@ func BenchmarkMillerLoop(b *testing.B) {
 		MillerLoop([]G1Affine{g1GenAff}, []G2Affine{g2GenAff})
 	}
 }
+func BenchmarkMillerLoopFixedQ(b *testing.B) {
+
+	var g1GenAff G1Affine
+	var g2GenAff G2Affine
+
+	g1GenAff.FromJacobian(&g1Gen)
+	g2GenAff.FromJacobian(&g2Gen)
+
+	b.Run("MillerLoop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MillerLoop([]G1Affine{g1GenAff}, []G2Affine{g2GenAff})
+		}
+	})
+
+	b.Run("PrecomputeLines", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			PrecomputeLines(g2GenAff)
+		}
+	})
+
+	lines := []PrecomputedLines{PrecomputeLines(g2GenAff)}
+	b.Run("MillerLoopFixedQ", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MillerLoopFixedQ([]G1Affine{g1GenAff}, lines)
+		}
+	})
+}
 
 func BenchmarkFinalExponentiation(b *testing.B) {
 
@@ -379,6 +888,30 @@ func BenchmarkMultiPair(b *testing.B) {
 	}
 }
 
+func BenchmarkPairBatch(b *testing.B) {
+
+	var g1GenAff G1Affine
+	var g2GenAff G2Affine
+
+	g1GenAff.FromJacobian(&g1Gen)
+	g2GenAff.FromJacobian(&g2Gen)
+
+	for _, n := range []int{1, 8, 32} {
+		P := make([]G1Affine, n)
+		Q := make([]G2Affine, n)
+		for j := 0; j < n; j++ {
+			P[j].Set(&g1GenAff)
+			Q[j].Set(&g2GenAff)
+		}
+		b.Run(fmt.Sprintf("%d pairs", n), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				PairBatch(P, Q)
+			}
+		})
+	}
+}
+
 func BenchmarkExpGT(b *testing.B) {
 
 	var a GT
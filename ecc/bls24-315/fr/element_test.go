@@ -17,9 +17,13 @@
 package fr
 
 import (
+	"bytes"
 	"crypto/rand"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"math/big"
 	"math/bits"
 
@@ -97,6 +101,13 @@ func BenchmarkElementMulByConstants(b *testing.B) {
 			MulBy13(&benchResElement)
 		}
 	})
+	b.Run("mulByConstant7", func(b *testing.B) {
+		benchResElement.SetRandom()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			MulByConstant(&benchResElement, 7)
+		}
+	})
 }
 
 func BenchmarkElementInverse(b *testing.B) {
@@ -201,6 +212,28 @@ func BenchmarkElementSquare(b *testing.B) {
 	}
 }
 
+// BenchmarkElementSquareN compares SquareN to a loop of Square.
+func BenchmarkElementSquareN(b *testing.B) {
+	const n = 64
+	var a Element
+	a.SetRandom()
+
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchResElement.Set(&a)
+			for j := 0; j < n; j++ {
+				benchResElement.Square(&benchResElement)
+			}
+		}
+	})
+
+	b.Run("SquareN", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchResElement.SquareN(&a, n)
+		}
+	})
+}
+
 func BenchmarkElementSqrt(b *testing.B) {
 	var a Element
 	a.SetUint64(4)
@@ -474,6 +507,254 @@ func TestElementBytes(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestElementWriteToReadFrom(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	// round trip of several elements through a buffer
+	const n = 10
+	var in, out [n]Element
+	for i := 0; i < n; i++ {
+		in[i].SetRandom()
+	}
+	in[0].SetZero()
+	in[1].SetOne().Neg(&in[1])
+
+	var buf bytes.Buffer
+	for i := 0; i < n; i++ {
+		written, err := in[i].WriteTo(&buf)
+		assert.NoError(err)
+		assert.Equal(int64(Bytes), written)
+	}
+	assert.Equal(n*Bytes, buf.Len())
+
+	// the encoding is the canonical big-endian one
+	expected := in[2].Bytes()
+	assert.Equal(expected[:], buf.Bytes()[2*Bytes:3*Bytes])
+
+	for i := 0; i < n; i++ {
+		read, err := out[i].ReadFrom(&buf)
+		assert.NoError(err)
+		assert.Equal(int64(Bytes), read)
+		assert.True(in[i].Equal(&out[i]))
+	}
+
+	// non canonical encoding
+	var q [Bytes]byte
+	Modulus().FillBytes(q[:])
+	a := out[2]
+	_, err := a.ReadFrom(bytes.NewReader(q[:]))
+	assert.Error(err)
+	assert.True(a.Equal(&out[2]), "z should be left unchanged on error")
+
+	// short read
+	read, err := a.ReadFrom(bytes.NewReader(q[1:]))
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+	assert.Equal(int64(Bytes-1), read)
+	_, err = a.ReadFrom(&buf)
+	assert.ErrorIs(err, io.EOF)
+}
+
+func TestElementSetBytesCanonical(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	// canonical encodings are accepted
+	for _, v := range []uint64{0, 1, 42} {
+		a := NewElement(v)
+		var b Element
+		buf := a.Bytes()
+		assert.NoError(b.SetBytesCanonical(buf[:]))
+		assert.True(a.Equal(&b))
+	}
+	var a, b Element
+	a.SetRandom()
+	buf := a.Bytes()
+	assert.NoError(b.SetBytesCanonical(buf[:]))
+	assert.True(a.Equal(&b))
+
+	// q - 1 is the largest canonical encoding
+	var bv big.Int
+	bv.Sub(Modulus(), big.NewInt(1)).FillBytes(buf[:])
+	assert.NoError(b.SetBytesCanonical(buf[:]))
+	a.SetOne().Neg(&a)
+	assert.True(a.Equal(&b))
+
+	// q, q+1, ... are not canonical, but SetBytes reduces them
+	for k := int64(0); k < 4; k++ {
+		bv.Add(Modulus(), big.NewInt(k)).FillBytes(buf[:])
+		b = a
+		assert.Error(b.SetBytesCanonical(buf[:]), "q+%d should be rejected", k)
+		assert.True(b.Equal(&a), "z should be left unchanged on error")
+
+		var c, expected Element
+		c.SetBytes(buf[:])
+		expected.SetUint64(uint64(k))
+		assert.True(c.Equal(&expected))
+	}
+
+	// wrong length
+	assert.Error(b.SetBytesCanonical(buf[1:]))
+	assert.Error(b.SetBytesCanonical(append(buf[:], 0)))
+}
+
+func TestIsCanonicalBytes(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	var a Element
+	a.SetRandom()
+	for _, e := range []Element{NewElement(0), NewElement(1), a} {
+		buf := e.Bytes()
+		assert.True(IsCanonicalBytes(buf[:]))
+	}
+
+	// q - 1 is canonical, q, q+1, ... are not
+	var buf [Bytes]byte
+	var bv big.Int
+	bv.Sub(Modulus(), big.NewInt(1)).FillBytes(buf[:])
+	assert.True(IsCanonicalBytes(buf[:]))
+	for k := int64(0); k < 4; k++ {
+		bv.Add(Modulus(), big.NewInt(k)).FillBytes(buf[:])
+		assert.False(IsCanonicalBytes(buf[:]), "q+%d should not be canonical", k)
+	}
+	for i := range buf {
+		buf[i] = 0xff
+	}
+	assert.False(IsCanonicalBytes(buf[:]))
+
+	// wrong length
+	assert.False(IsCanonicalBytes(buf[1:]))
+	assert.False(IsCanonicalBytes(append(buf[:], 0)))
+	assert.False(IsCanonicalBytes(nil))
+}
+
+func TestElementFromBytes(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	dst := []byte("fr-FromBytes-test")
+
+	// determinism
+	a := FromBytes(dst, []byte("msg"))
+	b := FromBytes(dst, []byte("msg"))
+	assert.True(a.Equal(&b), "FromBytes should be deterministic")
+
+	// different messages and domains give different outputs
+	c := FromBytes(dst, []byte("msg2"))
+	assert.False(a.Equal(&c), "different messages should give different outputs")
+	d := FromBytes([]byte("another-dst"), []byte("msg"))
+	assert.False(a.Equal(&d), "different domains should give different outputs")
+	e := FromBytes(nil, nil)
+	f := FromBytes(nil, []byte{0})
+	assert.False(e.Equal(&f))
+
+	// the output must be reduced
+	for _, x := range []Element{a, c, d, e, f} {
+		assert.True(x.smallerThanModulus())
+	}
+
+	// approximate uniformity: about half of the outputs should be smaller than q/2
+	const nbSamples = 1000
+	var halfQ, v big.Int
+	halfQ.Rsh(Modulus(), 1)
+	var msg [8]byte
+	nbSmall := 0
+	for i := 0; i < nbSamples; i++ {
+		binary.BigEndian.PutUint64(msg[:], uint64(i))
+		x := FromBytes(dst, msg[:])
+		x.ToBigIntRegular(&v)
+		if v.Cmp(&halfQ) <= 0 {
+			nbSmall++
+		}
+	}
+	// the standard deviation is √1000/2 ≈ 16
+	assert.True(nbSmall > nbSamples/2-100 && nbSmall < nbSamples/2+100, "outputs don't look uniform: %d/%d in [0, q/2]", nbSmall, nbSamples)
+
+	assert.Panics(func() { FromBytes(make([]byte, 256), nil) })
+}
+
+func TestElementHashToScalar(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	R, pub, msg := []byte("R"), []byte("public key"), []byte("message")
+
+	// determinism
+	a := HashToScalar(R, pub, msg)
+	b := HashToScalar(R, pub, msg)
+	assert.True(a.Equal(&b), "HashToScalar should be deterministic")
+	assert.True(a.smallerThanModulus())
+
+	// reordering the parts changes the output
+	c := HashToScalar(pub, R, msg)
+	assert.False(a.Equal(&c), "reordering the parts should change the output")
+	d := HashToScalar(R, msg, pub)
+	assert.False(a.Equal(&d), "reordering the parts should change the output")
+
+	// the parts are not simply concatenated
+	e := HashToScalar([]byte("ab"), []byte("c"))
+	f := HashToScalar([]byte("a"), []byte("bc"))
+	g := HashToScalar([]byte("abc"))
+	assert.False(e.Equal(&f), "moving bytes between parts should change the output")
+	assert.False(e.Equal(&g), "merging parts should change the output")
+	h := HashToScalar()
+	i := HashToScalar(nil)
+	assert.False(h.Equal(&i), "an empty part should change the output")
+
+	// domain separation from FromBytes on the raw concatenation
+	j := FromBytes(nil, []byte("abc"))
+	assert.False(g.Equal(&j))
+}
+
+func TestElementBigIntRoundTrip(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	q := Modulus()
+	var one, qMinusOne big.Int
+	one.SetUint64(1)
+	qMinusOne.Sub(q, &one)
+
+	// values in [0, q) round-trip
+	for _, v := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(42), &qMinusOne} {
+		var e Element
+		var r big.Int
+		e.SetBigInt(v).ToBigIntRegular(&r)
+		assert.Equal(0, r.Cmp(v), "SetBigInt(%s).ToBigIntRegular() should round-trip", v.String())
+	}
+	for i := 0; i < 10; i++ {
+		var e Element
+		var r big.Int
+		v, err := rand.Int(rand.Reader, q)
+		assert.NoError(err)
+		e.SetBigInt(v).ToBigIntRegular(&r)
+		assert.Equal(0, r.Cmp(v), "SetBigInt(%s).ToBigIntRegular() should round-trip", v.String())
+
+		// ToBigInt returns the Montgomery form
+		var m, expected big.Int
+		e.ToBigInt(&m)
+		expected.Lsh(v, Limbs*64).Mod(&expected, q)
+		assert.Equal(0, m.Cmp(&expected), "ToBigInt should return the Montgomery form")
+	}
+
+	// values out of [0, q) are reduced
+	for _, v := range []*big.Int{
+		new(big.Int).Set(q),
+		new(big.Int).Add(q, &one),
+		new(big.Int).Mul(q, big.NewInt(3)),
+		new(big.Int).Add(new(big.Int).Lsh(q, 3), big.NewInt(42)),
+		big.NewInt(-1),
+		big.NewInt(-42),
+	} {
+		var e Element
+		var r, expected big.Int
+		e.SetBigInt(v).ToBigIntRegular(&r)
+		expected.Mod(v, q)
+		assert.Equal(0, r.Cmp(&expected), "SetBigInt(%s) should reduce mod q", v.String())
+	}
+}
+
 func TestElementInverseExp(t *testing.T) {
 	// inverse must be equal to exp^-2
 	exp := Modulus()
@@ -550,6 +831,22 @@ func TestElementMulByConstants(t *testing.T) {
 		genA,
 	))
 
+	properties.Property("MulByConstant(x, c) == Mul(x, c)", prop.ForAll(
+		func(a testPairElement, c uint8) bool {
+			var constant Element
+			constant.SetUint64(uint64(c))
+
+			b := a.element
+			b.Mul(&b, &constant)
+
+			MulByConstant(&a.element, c)
+
+			return a.element.Equal(&b)
+		},
+		genA,
+		ggen.UInt8(),
+	))
+
 	properties.Property("MulBy3(x) == Mul(x, 3)", prop.ForAll(
 		func(a testPairElement) bool {
 			var constant Element
@@ -623,6 +920,231 @@ func TestElementLegendre(t *testing.T) {
 
 }
 
+func TestElementEulerCriterion(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+
+	var one, minusOne Element
+	one.SetOne()
+	minusOne.Neg(&one)
+
+	properties.Property("EulerCriterion(x) should be 1 on squares", prop.ForAll(
+		func(a testPairElement) bool {
+			var x, c Element
+			x.Square(&a.element)
+			c.EulerCriterion(&x)
+			if x.IsZero() {
+				return c.IsZero()
+			}
+			return c.Equal(&one)
+		},
+		genA,
+	))
+
+	properties.Property("EulerCriterion(x) should be ±1 or 0 as big.Int.Jacobi", prop.ForAll(
+		func(a testPairElement) bool {
+			var c Element
+			c.EulerCriterion(&a.element)
+			switch big.Jacobi(&a.bigint, Modulus()) {
+			case 1:
+				return c.Equal(&one)
+			case -1:
+				return c.Equal(&minusOne)
+			default:
+				return c.IsZero()
+			}
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// zero, and a non-square
+	var zero, c, x Element
+	if !c.EulerCriterion(&zero).IsZero() {
+		t.Fatal("EulerCriterion(0) should be 0")
+	}
+	x.SetUint64(2)
+	for x.Legendre() != -1 {
+		x.Add(&x, &one)
+	}
+	if !c.EulerCriterion(&x).Equal(&minusOne) {
+		t.Fatal("EulerCriterion of a non-square should be -1")
+	}
+}
+
+func TestElementBatchSqrt(t *testing.T) {
+	t.Parallel()
+
+	const n = 100
+	in := make([]Element, n)
+	for i := 0; i < n; i++ {
+		in[i].SetRandom()
+		if i%3 == 0 {
+			// make sure there are squares
+			in[i].Square(&in[i])
+		}
+	}
+	in[1].SetZero()
+
+	roots, isSquare := BatchSqrt(in)
+	if len(roots) != n || len(isSquare) != n {
+		t.Fatal("wrong output size")
+	}
+	for i := 0; i < n; i++ {
+		var expected Element
+		ok := expected.Sqrt(&in[i]) != nil
+		if ok != isSquare[i] || ok != in[i].IsSquare() {
+			t.Fatal("BatchSqrt residuosity flag doesn't match Sqrt")
+		}
+		if !ok {
+			expected.SetZero()
+		}
+		if !roots[i].Equal(&expected) {
+			t.Fatal("BatchSqrt doesn't match Sqrt")
+		}
+	}
+
+	if roots, isSquare := BatchSqrt(nil); len(roots) != 0 || len(isSquare) != 0 {
+		t.Fatal("BatchSqrt of an empty slice should be empty")
+	}
+}
+
+func BenchmarkElementBatchSqrt(b *testing.B) {
+	const n = 1 << 10
+	in := make([]Element, n)
+	for i := 0; i < n; i++ {
+		in[i].SetRandom()
+		in[i].Square(&in[i])
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchSqrt(in)
+	}
+}
+
+func TestElementGenerator(t *testing.T) {
+	t.Parallel()
+
+	// 2^TwoAdicity divides q-1, 2^(TwoAdicity+1) doesn't
+	e := TwoAdicity()
+	qMinusOne := new(big.Int).Sub(Modulus(), big.NewInt(1))
+	if uint64(qMinusOne.TrailingZeroBits()) != e {
+		t.Fatal("TwoAdicity doesn't match q-1")
+	}
+
+	var one Element
+	one.SetOne()
+
+	logSizes := []int{0, 1, 2, int(e / 2), int(e) - 1, int(e)}
+	for _, logSize := range logSizes {
+		if logSize < 0 || logSize > int(e) {
+			continue
+		}
+		g := Generator(logSize)
+
+		// g^(2^logSize) == 1
+		x := g
+		for i := 0; i < logSize; i++ {
+			x.Square(&x)
+		}
+		if !x.Equal(&one) {
+			t.Fatalf("Generator(%d) is not a 2^%d-th root of unity", logSize, logSize)
+		}
+
+		// g^(2^(logSize-1)) != 1
+		if logSize > 0 {
+			x = g
+			for i := 0; i < logSize-1; i++ {
+				x.Square(&x)
+			}
+			if x.Equal(&one) {
+				t.Fatalf("Generator(%d) is not a primitive 2^%d-th root of unity", logSize, logSize)
+			}
+
+			// roots are consistent across sizes
+			x.Square(&g)
+			if expected := Generator(logSize - 1); !x.Equal(&expected) {
+				t.Fatalf("Generator(%d)² != Generator(%d)", logSize, logSize-1)
+			}
+		}
+	}
+
+	// the 2-adic root of unity is derived from the multiplicative generator
+	var g, expected Element
+	g = MultiplicativeGenerator()
+	if g.Legendre() != -1 {
+		t.Fatal("MultiplicativeGenerator should not be a square")
+	}
+	g.Exp(g, new(big.Int).Rsh(qMinusOne, uint(e)))
+	expected = Generator(int(e))
+	if !g.Equal(&expected) {
+		t.Fatal("Generator(TwoAdicity()) != MultiplicativeGenerator()^((q-1)/2^TwoAdicity())")
+	}
+
+	for _, logSize := range []int{-1, int(e) + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Generator(%d) should panic", logSize)
+				}
+			}()
+			Generator(logSize)
+		}()
+	}
+}
+
+func TestElementIsSquare(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+
+	properties.Property("IsSquare should output true iff Sqrt exists", prop.ForAll(
+		func(a testPairElement) bool {
+			var s Element
+			return a.element.IsSquare() == (s.Sqrt(&a.element) != nil)
+		},
+		genA,
+	))
+
+	properties.Property("Sqrt(a²) should be ±a and a² should be a square", prop.ForAll(
+		func(a testPairElement) bool {
+			var square, root, neg Element
+			square.Square(&a.element)
+			if !square.IsSquare() || root.Sqrt(&square) == nil {
+				return false
+			}
+			neg.Neg(&a.element)
+			return root.Equal(&a.element) || root.Equal(&neg)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	var zero Element
+	if !zero.IsSquare() {
+		t.Fatal("0 should be a square")
+	}
+}
+
 func TestElementBitLen(t *testing.T) {
 	t.Parallel()
 	parameters := gopter.DefaultTestParameters()
@@ -714,6 +1236,47 @@ func TestElementLexicographicallyLargest(t *testing.T) {
 
 }
 
+func TestElementLexicographicallyLargestReference(t *testing.T) {
+	t.Parallel()
+
+	// reference definition: z > (q-1) / 2
+	var qMinusOneHalved big.Int
+	qMinusOneHalved.Sub(Modulus(), big.NewInt(1)).Rsh(&qMinusOneHalved, 1)
+	reference := func(e *Element) bool {
+		var b big.Int
+		e.ToBigIntRegular(&b)
+		return b.Cmp(&qMinusOneHalved) == 1
+	}
+
+	// boundary elements
+	boundaries := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		new(big.Int).Sub(&qMinusOneHalved, big.NewInt(1)),
+		new(big.Int).Set(&qMinusOneHalved),
+		new(big.Int).Add(&qMinusOneHalved, big.NewInt(1)),
+		new(big.Int).Add(&qMinusOneHalved, big.NewInt(2)),
+		new(big.Int).Sub(Modulus(), big.NewInt(2)),
+		new(big.Int).Sub(Modulus(), big.NewInt(1)),
+	}
+	for _, b := range boundaries {
+		var e Element
+		e.SetBigInt(b)
+		if e.LexicographicallyLargest() != reference(&e) {
+			t.Fatalf("LexicographicallyLargest mismatch for %s", b.String())
+		}
+	}
+
+	// random elements
+	for i := 0; i < 1000; i++ {
+		var e Element
+		e.SetRandom()
+		if e.LexicographicallyLargest() != reference(&e) {
+			t.Fatalf("LexicographicallyLargest mismatch for %s", e.String())
+		}
+	}
+}
+
 func TestElementAdd(t *testing.T) {
 	t.Parallel()
 	parameters := gopter.DefaultTestParameters()
@@ -1727,6 +2290,83 @@ func TestElementHalve(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestElementSquareN(t *testing.T) {
+
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genN := ggen.IntRange(-1, 70)
+
+	properties.Property("z.SquareN(x, n) must match n sequential squarings", prop.ForAll(
+		func(a testPairElement, n int) bool {
+			var c, d Element
+			c.SquareN(&a.element, n)
+			d.Set(&a.element)
+			for i := 0; i < n; i++ {
+				d.Square(&d)
+			}
+			return c.Equal(&d)
+		},
+		genA,
+		genN,
+	))
+
+	properties.Property("z.SquareN(z, n) (in place) must match z.SquareN(x, n)", prop.ForAll(
+		func(a testPairElement, n int) bool {
+			var c, d Element
+			c.SquareN(&a.element, n)
+			d.Set(&a.element)
+			d.SquareN(&d, n)
+			return c.Equal(&d)
+		},
+		genA,
+		genN,
+	))
+
+	properties.Property("z.SquareAssign() must match z.Square(z)", prop.ForAll(
+		func(a testPairElement) bool {
+			c := a.element
+			d := a.element
+			c.SquareAssign()
+			d.Square(&d)
+			return c.Equal(&d)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestElementDivByZero(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	var x, y, zero, z Element
+	x.SetRandom()
+	y.SetRandom()
+
+	// x / 0 = 0, even when the result aliases an operand
+	z.Div(&x, &zero)
+	assert.True(z.IsZero(), "x / 0 should be 0")
+	z.Set(&x)
+	z.Div(&z, &zero)
+	assert.True(z.IsZero(), "x / 0 should be 0 when z == x")
+
+	// (x / y) ⋅ y = x
+	if !y.IsZero() {
+		z.Div(&x, &y).Mul(&z, &y)
+		assert.True(z.Equal(&x))
+	}
+}
+
 func combineSelectionArguments(c int64, z int8) int {
 	if z%3 == 0 {
 		return 0
@@ -1818,6 +2458,154 @@ func TestElementSetInt64(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestElementCanonical(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	// q is a non-reduced representation of 0
+	nonReduced := qElement
+	var zero Element
+	assert.Equal(zero, nonReduced.Canonical(), "Canonical(q) should be 0")
+
+	// limbs in [2q, 2^(64⋅Limbs)) need more than one subtraction of q
+	var bound big.Int
+	bound.Lsh(big.NewInt(1), 64*Limbs)
+	rawValues := []*big.Int{new(big.Int).Sub(&bound, big.NewInt(1))}
+	for k := int64(2); k < 8; k++ {
+		v := new(big.Int).Mul(Modulus(), big.NewInt(k))
+		if v.Cmp(&bound) == -1 {
+			rawValues = append(rawValues, v, new(big.Int).Add(v, big.NewInt(1)))
+		}
+	}
+	for _, v := range rawValues {
+		var buf [Bytes]byte
+		v.FillBytes(buf[:])
+		var a Element
+		for i := 0; i < Limbs; i++ {
+			a[Limbs-1-i] = binary.BigEndian.Uint64(buf[8*i : 8*i+8])
+		}
+
+		var expected, got big.Int
+		expected.Mod(v, Modulus())
+		ca := a.Canonical()
+		assert.True(ca.IsReduced(), "Canonical(%s) should be reduced", v.String())
+		assert.Equal(0, ca.ToBigInt(&got).Cmp(&expected), "Canonical(%s) should be %s", v.String(), expected.String())
+		assert.Equal(ca, ca.Canonical(), "Canonical should be idempotent")
+	}
+
+	for i := 0; i < 100; i++ {
+		var a, b, c Element
+		a.SetRandom()
+		c.SetRandom()
+
+		// idempotent
+		ca := a.Canonical()
+		assert.Equal(ca, ca.Canonical(), "Canonical should be idempotent")
+		assert.True(ca.Equal(&a), "Canonical should preserve the value")
+
+		// equal elements have identical canonical limbs
+		b.Add(&a, &c).Sub(&b, &c)
+		assert.True(a.Equal(&b))
+		assert.Equal(a.Canonical(), b.Canonical(), "equal elements should have the same Canonical limbs")
+	}
+}
+
+func TestElementVecScale(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	for _, n := range []int{0, 1, 2, 17} {
+		a := make([]Element, n)
+		for i := range a {
+			a[i].SetRandom()
+		}
+		var s Element
+		s.SetRandom()
+
+		// per-element multiplication by the running power
+		expected := make([]Element, n)
+		power := One()
+		for i := range a {
+			expected[i].Mul(&a[i], &power)
+			power.Mul(&power, &s)
+		}
+
+		dst := make([]Element, n)
+		VecScale(dst, a, s)
+		assert.Equal(expected, dst)
+
+		// in place
+		VecScale(a, a, s)
+		assert.Equal(expected, a)
+	}
+
+	assert.Panics(func() { VecScale(make([]Element, 1), make([]Element, 2), One()) })
+}
+
+func TestElementIsReduced(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	// reduced limb patterns
+	var zero, qMinusOne Element
+	assert.True(zero.IsReduced())
+	qMinusOne = qElement
+	qMinusOne[0]--
+	assert.True(qMinusOne.IsReduced(), "q-1 should be reduced")
+	for i := 0; i < 100; i++ {
+		var a Element
+		a.SetRandom()
+		assert.True(a.IsReduced())
+		a.Add(&a, &a).Mul(&a, &a)
+		assert.True(a.IsReduced())
+	}
+
+	// non-reduced limb patterns
+	nonReduced := qElement
+	assert.False(nonReduced.IsReduced(), "q should not be reduced")
+	nonReduced[0]++
+	assert.False(nonReduced.IsReduced(), "q+1 should not be reduced")
+	for i := range nonReduced {
+		nonReduced[i] = ^uint64(0)
+	}
+	assert.False(nonReduced.IsReduced(), "all-ones limbs should not be reduced")
+}
+
+func TestElementSetInt64Negative(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	q := Modulus()
+
+	// -1 must be q - 1
+	var minusOne, expected Element
+	minusOne.SetInt64(-1)
+	expected.SetOne()
+	expected.Neg(&expected)
+	assert.True(minusOne.Equal(&expected), "SetInt64(-1) should be q-1")
+
+	var r, qMinusOne big.Int
+	minusOne.ToBigIntRegular(&r)
+	qMinusOne.Sub(q, big.NewInt(1))
+	assert.Equal(0, r.Cmp(&qMinusOne), "SetInt64(-1).ToBigIntRegular() should be q-1")
+
+	for _, v := range []int64{0, 1, -1, 42, -42, math.MaxInt64, math.MinInt64, math.MinInt64 + 1} {
+		var e Element
+		var r, expected big.Int
+		e.SetInt64(v).ToBigIntRegular(&r)
+		expected.Mod(big.NewInt(v), q)
+		assert.Equal(0, r.Cmp(&expected), "SetInt64(%d) should be %d mod q", v, v)
+
+		// v + (-v) == 0
+		if v != math.MinInt64 {
+			var neg Element
+			neg.SetInt64(-v)
+			neg.Add(&neg, &e)
+			assert.True(neg.IsZero(), "SetInt64(%d) + SetInt64(%d) should be 0", v, -v)
+		}
+	}
+}
+
 func TestElementSetInterface(t *testing.T) {
 
 	t.Parallel()
@@ -0,0 +1,184 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package merkletree
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/mimc"
+)
+
+func TestMerkleTree(t *testing.T) {
+
+	const nbLeaves = 13
+
+	tree := New(FromHash(mimc.NewMiMC()))
+	leaves := make([]fr.Element, nbLeaves)
+	for i := 0; i < nbLeaves; i++ {
+		leaves[i].SetRandom()
+		tree.Push(leaves[i])
+	}
+	root := tree.Root()
+	if expected := naiveRoot(FromHash(mimc.NewMiMC()), leaves); !root.Equal(&expected) {
+		t.Fatal("wrong root")
+	}
+
+	verifier := FromHash(mimc.NewMiMC())
+
+	for i := 0; i < nbLeaves; i++ {
+		proof, err := tree.Proof(uint64(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(proof.Path) != 4 {
+			t.Fatal("wrong proof length")
+		}
+		if err := VerifyProof(verifier, root, nbLeaves, leaves[i], proof); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// wrong leaf
+	proof, err := tree.Proof(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyProof(verifier, root, nbLeaves, leaves[4], proof) == nil {
+		t.Fatal("proof verified with the wrong leaf")
+	}
+
+	// tampered path
+	proof.Path[1].Add(&proof.Path[1], &leaves[0])
+	if VerifyProof(verifier, root, nbLeaves, leaves[3], proof) == nil {
+		t.Fatal("tampered proof verified")
+	}
+
+	// wrong index
+	proof, _ = tree.Proof(3)
+	proof.Index = 2
+	if VerifyProof(verifier, root, nbLeaves, leaves[3], proof) == nil {
+		t.Fatal("proof verified with the wrong index")
+	}
+	proof.Index = 3 + (1 << 4)
+	if VerifyProof(verifier, root, nbLeaves, leaves[3], proof) == nil {
+		t.Fatal("proof verified with an out of range index")
+	}
+
+	// wrong number of leaves
+	proof, _ = tree.Proof(3)
+	if VerifyProof(verifier, root, 1<<5, leaves[3], proof) == nil {
+		t.Fatal("proof verified with the wrong number of leaves")
+	}
+
+	// an internal node is not a leaf
+	proof, _ = tree.Proof(4)
+	internal := verifier.Leaf(&leaves[4])
+	internal = verifier.Compress(&internal, &proof.Path[0])
+	internal = verifier.Compress(&internal, &proof.Path[1])
+	proof.Index = 1
+	proof.Path = proof.Path[2:]
+	if VerifyProof(verifier, root, nbLeaves, internal, proof) == nil {
+		t.Fatal("internal node verified as a leaf")
+	}
+
+	// a padding leaf is not a leaf
+	proof, _ = tree.Proof(12)
+	proof.Index = 13
+	if VerifyProof(verifier, root, 1<<4, fr.Element{}, proof) == nil {
+		t.Fatal("padding leaf verified")
+	}
+
+	if _, err := tree.Proof(nbLeaves); err != ErrIndexOutOfRange {
+		t.Fatal("expected ErrIndexOutOfRange")
+	}
+
+	// pushing a leaf changes the root
+	var leaf fr.Element
+	leaf.SetRandom()
+	tree.Push(leaf)
+	newRoot := tree.Root()
+	if newRoot.Equal(&root) {
+		t.Fatal("root unchanged after Push")
+	}
+	if expected := naiveRoot(verifier, append(leaves, leaf)); !newRoot.Equal(&expected) {
+		t.Fatal("wrong root after Push")
+	}
+	proof, _ = tree.Proof(nbLeaves)
+	if err := VerifyProof(verifier, newRoot, nbLeaves+1, leaf, proof); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// naiveRoot hashes the leaves, pads them with zeros to a power of two and compresses
+// the layers one after the other
+func naiveRoot(h Hasher, leaves []fr.Element) fr.Element {
+	n := 1
+	for n < len(leaves) {
+		n <<= 1
+	}
+	layer := make([]fr.Element, n)
+	for i := range leaves {
+		layer[i] = h.Leaf(&leaves[i])
+	}
+	for len(layer) > 1 {
+		for i := 0; i < len(layer)/2; i++ {
+			layer[i] = h.Compress(&layer[2*i], &layer[2*i+1])
+		}
+		layer = layer[:len(layer)/2]
+	}
+	return layer[0]
+}
+
+func TestMerkleTreeSingleLeaf(t *testing.T) {
+	tree := New(FromHash(mimc.NewMiMC()))
+	root := tree.Root()
+	if !root.IsZero() {
+		t.Fatal("root of the empty tree should be 0")
+	}
+
+	var leaf fr.Element
+	leaf.SetRandom()
+	tree.Push(leaf)
+	root = tree.Root()
+	if expected := FromHash(mimc.NewMiMC()).Leaf(&leaf); !root.Equal(&expected) {
+		t.Fatal("root of a single leaf tree should be the hashed leaf")
+	}
+	proof, err := tree.Proof(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyProof(FromHash(mimc.NewMiMC()), root, 1, leaf, proof); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkMerkleTreeRoot(b *testing.B) {
+	const nbLeaves = 1 << 10
+	leaves := make([]fr.Element, nbLeaves)
+	for i := 0; i < nbLeaves; i++ {
+		leaves[i].SetRandom()
+	}
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		tree := New(FromHash(mimc.NewMiMC()))
+		for i := 0; i < nbLeaves; i++ {
+			tree.Push(leaves[i])
+		}
+		tree.Root()
+	}
+}
@@ -346,6 +346,37 @@ func HashToG1(msg, dst []byte) (G1Affine, error) {
 	return Q1, nil
 }
 
+// HashToTwoG1 hashes a message to two independent points on the G1 curve using the SSWU map.
+// This is useful for constructions (VRFs, Pedersen-style commitments) mapping one message to a pair (H, H').
+//
+// The message is expanded once into four field elements u₀, u₁, u₂, u₃ (hash_to_field with count = 4),
+// and H = clear_cofactor(map(u₀) + map(u₁)), H' = clear_cofactor(map(u₂) + map(u₃)).
+// Since the requested output length is part of the expand_message_xmd input, the outputs don't
+// collide with HashToG1 (count = 2), whatever the dst it is used with.
+// dst stands for "domain separation tag", a string unique to the construction using the hash function
+func HashToTwoG1(msg, dst []byte) (G1Affine, G1Affine, error) {
+	u, err := hashToFp(msg, dst, 4)
+	if err != nil {
+		return G1Affine{}, G1Affine{}, err
+	}
+
+	var res [2]G1Affine
+	for i := range res {
+		Q0 := mapToCurve1(&u[2*i])
+		Q1 := mapToCurve1(&u[2*i+1])
+
+		g1Isogeny(&Q0)
+		g1Isogeny(&Q1)
+
+		var _Q0, _Q1 G1Jac
+		_Q0.FromAffine(&Q0)
+		_Q1.FromAffine(&Q1).AddAssign(&_Q0)
+		_Q1.ClearCofactor(&_Q1)
+		res[i].FromJacobian(&_Q1)
+	}
+	return res[0], res[1], nil
+}
+
 func g1NotZero(x *fp.Element) uint64 {
 
 	return x[0] | x[1] | x[2] | x[3] | x[4]
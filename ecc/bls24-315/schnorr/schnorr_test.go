@@ -0,0 +1,340 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package schnorr
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func Example() {
+	// create a schnorr key pair
+	privateKey, _ := GenerateKey(crand.Reader)
+	publicKey := privateKey.Public()
+
+	msg := []byte("hello")
+
+	// sign the message
+	signature, _ := privateKey.Sign(msg)
+
+	// verifies signature
+	if !Verify(&publicKey, msg, &signature) {
+		fmt.Println("1. invalid signature")
+	} else {
+		fmt.Println("1. valid signature")
+	}
+
+	// Output: 1. valid signature
+}
+
+// testSeed returns the 32-byte seed 00 01 02 … 1f
+func testSeed() *bytes.Reader {
+	var seed [32]byte
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	return bytes.NewReader(seed[:])
+}
+
+func TestKnownAnswer(t *testing.T) {
+	privKey, err := GenerateKey(testSeed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := privKey.Sign([]byte("gnark-crypto"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(privKey.Bytes()); got != "a3f430b49f037f7c714168208b980a8b595e9ff512a90402f9b555a1d7dc892d4cb233612be650fc09860ef4ca6741d02995d338b717d3e6dae921a39c4fa5528b0208e184f6c72a" {
+		t.Fatalf("unexpected private key %s", got)
+	}
+	if got := hex.EncodeToString(sig.Bytes()); got != "a27ce42a5b29cf3612ce177895fa0f08156cac6a55c5cca17634ba1d7d9af41d63d882fd40e807eb0de4b19649fd227462c7b61a1d7131b35a25bf408a492f75e5e754f1d501ffde" {
+		t.Fatalf("unexpected signature %s", got)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 10
+	} else {
+		parameters.MinSuccessfulTests = 100
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	privKey, err := GenerateKey(crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := privKey.Public()
+	other, err := GenerateKey(crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub := other.Public()
+
+	properties.Property("[BLS24-315] a signature should verify", prop.ForAll(
+		func(msg []byte) bool {
+			sig, err := privKey.Sign(msg)
+			return err == nil && Verify(&pub, msg, &sig)
+		},
+		gen.SliceOf(gen.UInt8()),
+	))
+
+	properties.Property("[BLS24-315] signing should be deterministic", prop.ForAll(
+		func(msg []byte) bool {
+			sig1, err1 := privKey.Sign(msg)
+			sig2, err2 := privKey.Sign(msg)
+			return err1 == nil && err2 == nil && bytes.Equal(sig1.Bytes(), sig2.Bytes())
+		},
+		gen.SliceOf(gen.UInt8()),
+	))
+
+	properties.Property("[BLS24-315] a tampered signature, message or key should not verify", prop.ForAll(
+		func(msg []byte) bool {
+			sig, err := privKey.Sign(msg)
+			if err != nil {
+				return false
+			}
+
+			tamperedMsg := append([]byte{1}, msg...)
+
+			tamperedS := sig
+			tamperedS.S.Double(&tamperedS.S)
+
+			tamperedR := sig
+			tamperedR.R.Add(&tamperedR.R, &g1GenAff)
+
+			return !Verify(&pub, tamperedMsg, &sig) &&
+				!Verify(&pub, msg, &tamperedS) &&
+				!Verify(&pub, msg, &tamperedR) &&
+				!Verify(&otherPub, msg, &sig)
+		},
+		gen.SliceOf(gen.UInt8()),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestNoKeyPrefix(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := privKey.Public()
+	msg := []byte("message")
+
+	sig, err := privKey.Sign(msg, Config{NoKeyPrefix: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(&pub, msg, &sig, Config{NoKeyPrefix: true}) {
+		t.Fatal("a signature without key prefix should verify without key prefix")
+	}
+	if Verify(&pub, msg, &sig) {
+		t.Fatal("a signature without key prefix should not verify with key prefix")
+	}
+
+	sigPrefix, err := privKey.Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Verify(&pub, msg, &sigPrefix, Config{NoKeyPrefix: true}) {
+		t.Fatal("a signature with key prefix should not verify without key prefix")
+	}
+
+	// the two variants must not share nonces, or the private key could be recovered
+	// from the two signatures
+	if sig.R.Equal(&sigPrefix.R) {
+		t.Fatal("signing a message under both configs should use different nonces")
+	}
+}
+
+func TestInvalidPublicKey(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("message")
+	sig, err := privKey.Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var infinity bls24315.G1Affine
+	if Verify(&infinity, msg, &sig) {
+		t.Fatal("the point at infinity should not be accepted as a public key")
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	t.Parallel()
+
+	const n = 10
+	pubs := make([]bls24315.G1Affine, n)
+	msgs := make([][]byte, n)
+	sigs := make([]Signature, n)
+	for i := 0; i < n; i++ {
+		privKey, err := GenerateKey(crand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubs[i] = privKey.Public()
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		if sigs[i], err = privKey.Sign(msgs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if ok, invalid := BatchVerify(pubs, msgs, sigs); !ok || len(invalid) != 0 {
+		t.Fatal("a batch of valid signatures should verify")
+	}
+	if ok, invalid := BatchVerify(nil, nil, nil); !ok || len(invalid) != 0 {
+		t.Fatal("an empty batch should verify")
+	}
+	if ok, invalid := BatchVerify(pubs, msgs[:n-1], sigs); ok || len(invalid) != 0 {
+		t.Fatal("a batch with mismatched lengths should not verify")
+	}
+
+	// one bad signature
+	badSigs := make([]Signature, n)
+	copy(badSigs, sigs)
+	badSigs[3].S.Double(&badSigs[3].S)
+	ok, invalid := BatchVerify(pubs, msgs, badSigs)
+	if ok || len(invalid) != 1 || invalid[0] != 3 {
+		t.Fatalf("the bad signature should be identified, got %v", invalid)
+	}
+
+	// a wrong message and a wrong public key
+	badMsgs := make([][]byte, n)
+	copy(badMsgs, msgs)
+	badMsgs[1] = []byte("another message")
+	badPubs := make([]bls24315.G1Affine, n)
+	copy(badPubs, pubs)
+	badPubs[7] = pubs[8]
+	ok, invalid = BatchVerify(badPubs, badMsgs, sigs)
+	if ok || len(invalid) != 2 || invalid[0] != 1 || invalid[1] != 7 {
+		t.Fatalf("the bad signatures should be identified, got %v", invalid)
+	}
+
+	// the point at infinity as public key
+	badPubs[7] = bls24315.G1Affine{}
+	ok, invalid = BatchVerify(badPubs, msgs, sigs)
+	if ok || len(invalid) != 1 || invalid[0] != 7 {
+		t.Fatalf("the invalid public key should be identified, got %v", invalid)
+	}
+
+	// the configuration must match the one used by Sign
+	if ok, invalid := BatchVerify(pubs, msgs, sigs, Config{NoKeyPrefix: true}); ok || len(invalid) != n {
+		t.Fatal("signatures with key prefix should not verify without key prefix")
+	}
+}
+
+func TestSerialization(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := privKey.Sign([]byte("message"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var privKey2 PrivateKey
+	if n, err := privKey2.SetBytes(privKey.Bytes()); err != nil || n != SizePrivateKey {
+		t.Fatal("couldn't deserialize the private key", err)
+	}
+	if !bytes.Equal(privKey2.Bytes(), privKey.Bytes()) {
+		t.Fatal("Error serialize(deserialize(.))")
+	}
+
+	var sig2 Signature
+	if n, err := sig2.SetBytes(sig.Bytes()); err != nil || n != SizeSignature {
+		t.Fatal("couldn't deserialize the signature", err)
+	}
+	if !bytes.Equal(sig2.Bytes(), sig.Bytes()) {
+		t.Fatal("Error serialize(deserialize(.))")
+	}
+
+	// a private key whose public key doesn't match its scalar is rejected
+	other, err := GenerateKey(crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := privKey.Bytes()
+	copy(buf[sizePublicKey:], other.Bytes()[sizePublicKey:])
+	if _, err := privKey2.SetBytes(buf); err == nil {
+		t.Fatal("an inconsistent private key should be rejected")
+	}
+
+	if _, err := sig2.SetBytes(sig.Bytes()[:SizeSignature-1]); err == nil {
+		t.Fatal("a short signature should be rejected")
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	privKey, _ := GenerateKey(crand.Reader)
+	msg := []byte("message")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = privKey.Sign(msg)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	privKey, _ := GenerateKey(crand.Reader)
+	pub := privKey.Public()
+	msg := []byte("message")
+	sig, _ := privKey.Sign(msg)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Verify(&pub, msg, &sig)
+	}
+}
+
+func BenchmarkBatchVerify(b *testing.B) {
+	const n = 64
+	pubs := make([]bls24315.G1Affine, n)
+	msgs := make([][]byte, n)
+	sigs := make([]Signature, n)
+	for i := 0; i < n; i++ {
+		privKey, _ := GenerateKey(crand.Reader)
+		pubs[i] = privKey.Public()
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		sigs[i], _ = privKey.Sign(msgs[i])
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchVerify(pubs, msgs, sigs)
+	}
+}
@@ -17,6 +17,7 @@
 package fft
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -61,32 +62,47 @@ type Domain struct {
 	CosetTableInvReversed []fr.Element // optional, this is computed on demand at the creation of the domain
 }
 
+// ErrInvalidShift is returned by NewDomainWithShift when the coset shift lies in the subgroup
+var ErrInvalidShift = errors.New("the coset shift must not be in the subgroup of the domain")
+
 // NewDomain returns a subgroup with a power of 2 cardinality
 // cardinality >= m
 func NewDomain(m uint64) *Domain {
+	return newDomain(m, fr.MultiplicativeGenerator())
+}
+
+// NewDomainWithShift returns a subgroup with a power of 2 cardinality >= m,
+// whose coset tables are generated by shift instead of the generator of Fr*.
+//
+// It returns ErrInvalidShift if shift^cardinality == 1 (or shift == 0), since the coset
+// shift.<g> would then be the subgroup itself and the coset FFT would silently degenerate.
+func NewDomainWithShift(m uint64, shift fr.Element) (*Domain, error) {
+	var check fr.Element
+	check.Exp(shift, new(big.Int).SetUint64(ecc.NextPowerOfTwo(m)))
+	if shift.IsZero() || check.IsOne() {
+		return nil, ErrInvalidShift
+	}
+	return newDomain(m, shift), nil
+}
+
+func newDomain(m uint64, shift fr.Element) *Domain {
 
 	domain := &Domain{}
 	x := ecc.NextPowerOfTwo(m)
 	domain.Cardinality = uint64(x)
 
-	// generator of the largest 2-adic subgroup
-	var rootOfUnity fr.Element
-
-	rootOfUnity.SetString("16532287748948254263922689505213135976137839535221842169193829039521719560631")
-	const maxOrderRoot uint64 = 60
-	domain.FrMultiplicativeGen.SetUint64(7)
-
+	// coset shift, a generator of Fr* by default
+	domain.FrMultiplicativeGen = shift
 	domain.FrMultiplicativeGenInv.Inverse(&domain.FrMultiplicativeGen)
 
 	// find generator for Z/2^(log(m))Z
 	logx := uint64(bits.TrailingZeros64(x))
-	if logx > maxOrderRoot {
+	if logx > fr.TwoAdicity() {
 		panic(fmt.Sprintf("m (%d) is too big: the required root of unity does not exist", m))
 	}
 
 	// Generator = FinerGenerator^2 has order x
-	expo := uint64(1 << (maxOrderRoot - logx))
-	domain.Generator.Exp(rootOfUnity, big.NewInt(int64(expo))) // order x
+	domain.Generator = fr.Generator(int(logx)) // order x
 	domain.GeneratorInv.Inverse(&domain.Generator)
 	domain.CardinalityInv.SetUint64(uint64(x)).Inverse(&domain.CardinalityInv)
 
@@ -20,6 +20,8 @@ import (
 	"bytes"
 	"reflect"
 	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
 )
 
 func TestDomainSerialization(t *testing.T) {
@@ -45,3 +47,40 @@ func TestDomainSerialization(t *testing.T) {
 		t.Fatal("Domain.SetBytes(Bytes()) failed")
 	}
 }
+
+func TestDomainWithShift(t *testing.T) {
+
+	const size = 1 << 6
+
+	// a valid coset shift is accepted, and used for the coset tables
+	var shift fr.Element
+	shift.SetUint64(3)
+	domain, err := NewDomainWithShift(size, shift)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !domain.FrMultiplicativeGen.Equal(&shift) {
+		t.Fatal("the coset shift should be the one provided")
+	}
+	if !domain.CosetTable[1].Equal(&shift) {
+		t.Fatal("the coset table should be generated by the shift")
+	}
+
+	// the default shift is accepted, and gives the same domain as NewDomain
+	domain, err = NewDomainWithShift(size, fr.MultiplicativeGenerator())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(domain, NewDomain(size)) {
+		t.Fatal("NewDomainWithShift with the generator of Fr* should match NewDomain")
+	}
+
+	// shifts in the subgroup are rejected
+	var zero fr.Element
+	inSubgroup := []fr.Element{fr.Generator(6), fr.Generator(3), fr.Generator(0), zero}
+	for i := range inSubgroup {
+		if _, err := NewDomainWithShift(size, inSubgroup[i]); err != ErrInvalidShift {
+			t.Fatal("expected ErrInvalidShift")
+		}
+	}
+}
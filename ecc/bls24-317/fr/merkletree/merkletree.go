@@ -0,0 +1,208 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package merkletree
+
+import (
+	"errors"
+	"hash"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+var (
+	ErrIndexOutOfRange = errors.New("merkletree: leaf index out of range")
+	ErrInvalidProof    = errors.New("merkletree: invalid proof")
+)
+
+// Hasher hashes the leaves of the tree, and compresses two nodes into their parent.
+//
+// Leaf and Compress must be domain separated, otherwise an internal node
+// can be passed off as a leaf.
+type Hasher interface {
+	Leaf(leaf *fr.Element) fr.Element
+	Compress(left, right *fr.Element) fr.Element
+}
+
+// domain separation tags of the leaves and of the internal nodes, see FromHash
+var (
+	leafTag = fr.NewElement(0)
+	nodeTag = fr.NewElement(1)
+)
+
+// hashAdapter wraps a hash.Hash into a Hasher
+type hashAdapter struct {
+	h hash.Hash
+}
+
+// FromHash returns a Hasher which writes the canonical encodings of a tag followed by
+// the inputs to h and interprets the digest as an fr.Element (e.g. FromHash(mimc.NewMiMC())).
+// The tag is 0 for leaves and 1 for internal nodes.
+//
+// The returned Hasher is not safe for concurrent use.
+func FromHash(h hash.Hash) Hasher {
+	return hashAdapter{h: h}
+}
+
+// Leaf implements Hasher
+func (a hashAdapter) Leaf(leaf *fr.Element) fr.Element {
+	return a.sum(&leafTag, leaf)
+}
+
+// Compress implements Hasher
+func (a hashAdapter) Compress(left, right *fr.Element) fr.Element {
+	return a.sum(&nodeTag, left, right)
+}
+
+func (a hashAdapter) sum(inputs ...*fr.Element) fr.Element {
+	a.h.Reset()
+	for _, e := range inputs {
+		b := e.Bytes()
+		a.h.Write(b[:])
+	}
+
+	var res fr.Element
+	res.SetBytes(a.h.Sum(nil))
+	return res
+}
+
+// Tree is an incremental Merkle tree over fr.
+//
+// Leaves are pushed one at a time and hashed with Hasher.Leaf; the hashed
+// leaves are padded with zeros up to the next power of two.
+type Tree struct {
+	h Hasher
+
+	// layers[0] holds the hashed leaves, layers[i+1] the parents of layers[i].
+	// Missing right children are zeros[i].
+	layers [][]fr.Element
+	zeros  []fr.Element // zeros[i] is the root of a subtree of depth i with zero leaves
+}
+
+// Proof is a membership proof for the leaf at position Index.
+//
+// Path lists the siblings from the leaf layer up to (excluding) the root.
+type Proof struct {
+	Index uint64
+	Path  []fr.Element
+}
+
+// New returns an empty tree using h to hash leaves and compress nodes
+func New(h Hasher) *Tree {
+	return &Tree{h: h}
+}
+
+// Push appends leaf to the tree, updating the nodes on its path to the root
+func (t *Tree) Push(leaf fr.Element) {
+	if len(t.layers) == 0 {
+		t.layers = [][]fr.Element{nil}
+	}
+	index := len(t.layers[0])
+	t.layers[0] = append(t.layers[0], t.h.Leaf(&leaf))
+
+	for i := 0; len(t.layers[i]) > 1; i++ {
+		if i+1 == len(t.layers) {
+			t.layers = append(t.layers, nil)
+		}
+		left := t.layers[i][index&^1]
+		right := t.zero(i)
+		if index|1 < len(t.layers[i]) {
+			right = t.layers[i][index|1]
+		}
+		parent := t.h.Compress(&left, &right)
+		index >>= 1
+		if index == len(t.layers[i+1]) {
+			t.layers[i+1] = append(t.layers[i+1], parent)
+		} else {
+			t.layers[i+1][index] = parent
+		}
+	}
+}
+
+// NbLeaves returns the number of leaves pushed so far
+func (t *Tree) NbLeaves() int {
+	if len(t.layers) == 0 {
+		return 0
+	}
+	return len(t.layers[0])
+}
+
+// Root returns the root of the tree. The root of the empty tree is 0.
+func (t *Tree) Root() fr.Element {
+	if len(t.layers) == 0 {
+		return fr.Element{}
+	}
+	return t.layers[len(t.layers)-1][0]
+}
+
+// Proof returns a membership proof for the leaf at position index
+func (t *Tree) Proof(index uint64) (Proof, error) {
+	if index >= uint64(t.NbLeaves()) {
+		return Proof{}, ErrIndexOutOfRange
+	}
+	proof := Proof{
+		Index: index,
+		Path:  make([]fr.Element, len(t.layers)-1),
+	}
+	for i := 0; i < len(t.layers)-1; i++ {
+		if index^1 < uint64(len(t.layers[i])) {
+			proof.Path[i] = t.layers[i][index^1]
+		} else {
+			proof.Path[i] = t.zero(i)
+		}
+		index >>= 1
+	}
+	return proof, nil
+}
+
+// VerifyProof checks that proof attests that leaf is in the tree of root root
+// with nbLeaves leaves, at position proof.Index.
+func VerifyProof(h Hasher, root fr.Element, nbLeaves uint64, leaf fr.Element, proof Proof) error {
+	if proof.Index >= nbLeaves {
+		return ErrInvalidProof
+	}
+	if len(proof.Path) != bits.TrailingZeros64(ecc.NextPowerOfTwo(nbLeaves)) {
+		return ErrInvalidProof
+	}
+	index := proof.Index
+	current := h.Leaf(&leaf)
+	for i := 0; i < len(proof.Path); i++ {
+		if index&1 == 0 {
+			current = h.Compress(&current, &proof.Path[i])
+		} else {
+			current = h.Compress(&proof.Path[i], &current)
+		}
+		index >>= 1
+	}
+	if !current.Equal(&root) {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// zero returns the root of a subtree of depth i whose hashed leaves are all 0
+func (t *Tree) zero(i int) fr.Element {
+	if len(t.zeros) == 0 {
+		t.zeros = make([]fr.Element, 1)
+	}
+	for len(t.zeros) <= i {
+		z := t.zeros[len(t.zeros)-1]
+		t.zeros = append(t.zeros, t.h.Compress(&z, &z))
+	}
+	return t.zeros[i]
+}
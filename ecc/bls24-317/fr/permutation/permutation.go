@@ -60,6 +60,15 @@ type Proof struct {
 	shiftedProof kzg.OpeningProof
 }
 
+// Commitments returns the commitments to t1 and t2, the vectors proven to be permutations
+// of each other, so that a caller can bind them to its own commitments.
+//
+// Verify only checks that the committed vectors are permutations of each other;
+// it is up to the caller to check that they are the expected ones.
+func (proof *Proof) Commitments() (t1, t2 kzg.Digest) {
+	return proof.t1, proof.t2
+}
+
 // evaluateAccumulationPolynomialBitReversed returns the accumulation polynomial in Lagrange basis.
 func evaluateAccumulationPolynomialBitReversed(lt1, lt2 []fr.Element, epsilon fr.Element) []fr.Element {
 
@@ -163,10 +172,8 @@ func Prove(srs *kzg.SRS, t1, t2 []fr.Element) (Proof, error) {
 	ct2 := make([]fr.Element, s)
 	copy(ct1, t1)
 	copy(ct2, t2)
-	d.FFTInverse(ct1, fft.DIF)
-	d.FFTInverse(ct2, fft.DIF)
-	fft.BitReverse(ct1)
-	fft.BitReverse(ct2)
+	d.LagrangeToMonomial(ct1)
+	d.LagrangeToMonomial(ct2)
 	proof.t1, err = kzg.Commit(ct1, srs)
 	if err != nil {
 		return proof, err
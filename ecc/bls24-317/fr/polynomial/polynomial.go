@@ -17,7 +17,9 @@
 package polynomial
 
 import (
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
 	"github.com/consensys/gnark-crypto/utils"
 	"strconv"
 
@@ -46,6 +48,79 @@ func (p *Polynomial) Eval(v *fr.Element) fr.Element {
 	return res
 }
 
+// PadToPowerOfTwo returns a copy of p padded with zeros to the next power of 2 (or to 1 if p is empty).
+//
+// Zero-padding leaves the polynomial unchanged, so it is the padding to use on
+// coefficients, e.g. before an FFT. See PadWithLast for the padding of evaluations.
+func PadToPowerOfTwo(p []fr.Element) []fr.Element {
+	res := make([]fr.Element, ecc.NextPowerOfTwo(uint64(len(p))))
+	copy(res, p)
+	return res
+}
+
+// PadWithLast returns a copy of p of size n, padded by repeating the last element of p.
+//
+// Unlike PadToPowerOfTwo, this changes the polynomial; it is meant for vectors of values
+// such as lookup tables (see plookup), whose set of values is unchanged by the padding.
+// p must not be empty, and n must be larger than or equal to len(p).
+func PadWithLast(p []fr.Element, n int) []fr.Element {
+	res := make([]fr.Element, n)
+	copy(res, p)
+	for i := len(p); i < n; i++ {
+		res[i] = p[len(p)-1]
+	}
+	return res
+}
+
+// EvalMany evaluates each of the polynomials polys[i], given by their coefficients, at z
+// and returns [polys[0](z), polys[1](z), ...]; an empty polynomial evaluates to 0.
+//
+// The polynomials are evaluated in parallel;
+// nbTasks optionally caps the number of go routines used (default: runtime.NumCPU()).
+func EvalMany(polys [][]fr.Element, z fr.Element, nbTasks ...int) []fr.Element {
+	res := make([]fr.Element, len(polys))
+	parallel.Execute(len(polys), func(start, end int) {
+		for i := start; i < end; i++ {
+			if len(polys[i]) == 0 {
+				continue
+			}
+			p := Polynomial(polys[i])
+			res[i] = p.Eval(&z)
+		}
+	}, nbTasks...)
+	return res
+}
+
+// FoldVectors returns the random linear combination Σᵢ rⁱ⋅vs[i] of the vectors vs,
+// i.e. res[j] = vs[0][j] + r⋅vs[1][j] + r²⋅vs[2][j] + ...
+//
+// The vectors must have the same size, FoldVectors panics otherwise;
+// it returns an empty vector if vs is empty.
+// The indices are folded in parallel;
+// nbTasks optionally caps the number of go routines used (default: runtime.NumCPU()).
+func FoldVectors(vs [][]fr.Element, r fr.Element, nbTasks ...int) []fr.Element {
+	if len(vs) == 0 {
+		return []fr.Element{}
+	}
+	for i := 1; i < len(vs); i++ {
+		if len(vs[i]) != len(vs[0]) {
+			panic("the vectors to fold must have the same size")
+		}
+	}
+
+	res := make([]fr.Element, len(vs[0]))
+	parallel.Execute(len(res), func(start, end int) {
+		for j := start; j < end; j++ {
+			// Horner
+			for i := len(vs) - 1; i >= 0; i-- {
+				res[j].Mul(&res[j], &r).
+					Add(&res[j], &vs[i][j])
+			}
+		}
+	}, nbTasks...)
+	return res
+}
+
 // Clone returns a copy of the polynomial
 func (p *Polynomial) Clone() Polynomial {
 	_p := make(Polynomial, len(*p))
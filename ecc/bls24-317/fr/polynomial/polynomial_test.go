@@ -54,6 +54,166 @@ func TestPolynomialEval(t *testing.T) {
 	}
 }
 
+func TestEvalMany(t *testing.T) {
+
+	// polynomials of various degrees, including an empty one
+	polys := make([][]fr.Element, 30)
+	for i := range polys {
+		polys[i] = make([]fr.Element, i)
+		for j := range polys[i] {
+			polys[i][j].SetRandom()
+		}
+	}
+
+	var z fr.Element
+	z.SetRandom()
+
+	for _, nbTasks := range []int{1, 4, 0} {
+		evals := EvalMany(polys, z, nbTasks)
+		if len(evals) != len(polys) {
+			t.Fatal("EvalMany should return one evaluation per polynomial")
+		}
+		for i := range polys {
+			// Horner evaluation
+			var expected fr.Element
+			for j := len(polys[i]) - 1; j >= 0; j-- {
+				expected.Mul(&expected, &z).Add(&expected, &polys[i][j])
+			}
+			if !evals[i].Equal(&expected) {
+				t.Fatalf("EvalMany (nbTasks=%d): wrong evaluation of polynomial %d", nbTasks, i)
+			}
+		}
+	}
+
+	if len(EvalMany(nil, z)) != 0 {
+		t.Fatal("EvalMany of no polynomial should be empty")
+	}
+}
+
+func TestPadToPowerOfTwo(t *testing.T) {
+
+	for _, n := range []int{0, 1, 2, 3, 5, 8, 13} {
+		p := make([]fr.Element, n)
+		for i := range p {
+			p[i].SetRandom()
+		}
+		padded := PadToPowerOfTwo(p)
+
+		expectedSize := 1
+		for expectedSize < n {
+			expectedSize <<= 1
+		}
+		if len(padded) != expectedSize {
+			t.Fatalf("size %d: expected padded size %d, got %d", n, expectedSize, len(padded))
+		}
+		for i := range padded {
+			if i < n && !padded[i].Equal(&p[i]) {
+				t.Fatal("the coefficients of p should be kept")
+			}
+			if i >= n && !padded[i].IsZero() {
+				t.Fatal("p should be padded with zeros")
+			}
+		}
+
+		// the polynomial is unchanged
+		var z fr.Element
+		z.SetRandom()
+		_p, _padded := Polynomial(p), Polynomial(padded)
+		if n > 0 && _p.Eval(&z) != _padded.Eval(&z) {
+			t.Fatal("zero-padding should not change the polynomial")
+		}
+	}
+}
+
+func TestPadWithLast(t *testing.T) {
+
+	p := make([]fr.Element, 5)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	for _, n := range []int{5, 8, 13} {
+		padded := PadWithLast(p, n)
+		if len(padded) != n {
+			t.Fatalf("expected padded size %d, got %d", n, len(padded))
+		}
+		for i := range padded {
+			if i < len(p) && !padded[i].Equal(&p[i]) {
+				t.Fatal("the entries of p should be kept")
+			}
+			if i >= len(p) && !padded[i].Equal(&p[len(p)-1]) {
+				t.Fatal("p should be padded with its last element")
+			}
+		}
+	}
+
+	// the result is a copy
+	padded := PadWithLast(p, len(p))
+	padded[0].SetOne()
+	if p[0].Equal(&padded[0]) {
+		t.Fatal("PadWithLast should not alias p")
+	}
+}
+
+func TestFoldVectors(t *testing.T) {
+
+	const nbVectors, size = 4, 9
+	vs := make([][]fr.Element, nbVectors)
+	for i := range vs {
+		vs[i] = make([]fr.Element, size)
+		for j := range vs[i] {
+			vs[i][j].SetRandom()
+		}
+	}
+	var r fr.Element
+	r.SetRandom()
+
+	// the folding done inline by plookup before FoldVectors
+	expected := make([]fr.Element, size)
+	for i := 0; i < size; i++ {
+		for j := nbVectors - 1; j >= 0; j-- {
+			expected[i].Mul(&expected[i], &r).
+				Add(&expected[i], &vs[j][i])
+		}
+	}
+
+	for _, nbTasks := range []int{1, 3, 0} {
+		folded := FoldVectors(vs, r, nbTasks)
+		if len(folded) != size {
+			t.Fatalf("expected folded size %d, got %d", size, len(folded))
+		}
+		for i := range folded {
+			if !folded[i].Equal(&expected[i]) {
+				t.Fatal("FoldVectors should match the inline folding")
+			}
+		}
+	}
+
+	// Σᵢ rⁱ⋅vs[i] at index 0
+	var check, ri fr.Element
+	ri.SetOne()
+	for i := range vs {
+		var tmp fr.Element
+		tmp.Mul(&ri, &vs[i][0])
+		check.Add(&check, &tmp)
+		ri.Mul(&ri, &r)
+	}
+	if folded := FoldVectors(vs, r); !folded[0].Equal(&check) {
+		t.Fatal("FoldVectors should compute Σᵢ rⁱ⋅vs[i]")
+	}
+
+	if folded := FoldVectors(nil, r); len(folded) != 0 {
+		t.Fatal("folding no vectors should give an empty vector")
+	}
+
+	// vectors of different sizes
+	defer func() {
+		if recover() == nil {
+			t.Fatal("FoldVectors should panic on vectors of different sizes")
+		}
+	}()
+	FoldVectors([][]fr.Element{vs[0], vs[1][1:]}, r)
+}
+
 func TestPolynomialAddConstantInPlace(t *testing.T) {
 
 	// build polynomial
@@ -206,3 +366,32 @@ func TestPolynomialAdd(t *testing.T) {
 		t.Fatal("side effect, _f2 should not have been modified")
 	}
 }
+
+func BenchmarkEvalMany(b *testing.B) {
+	const nbPolys = 64
+	const size = 1 << 14
+	polys := make([][]fr.Element, nbPolys)
+	for i := range polys {
+		polys[i] = make([]fr.Element, size)
+		for j := range polys[i] {
+			polys[i][j].SetRandom()
+		}
+	}
+	var z fr.Element
+	z.SetRandom()
+
+	b.Run("sequential", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			for i := range polys {
+				p := Polynomial(polys[i])
+				p.Eval(&z)
+			}
+		}
+	})
+
+	b.Run("EvalMany", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			EvalMany(polys, z)
+		}
+	})
+}
@@ -18,6 +18,7 @@ package bls24317
 
 import (
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"io"
 	"reflect"
@@ -26,6 +27,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/internal/fptower"
+	"github.com/consensys/gnark-crypto/internal/bech32"
 	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
@@ -47,6 +49,23 @@ const (
 	mCompressedInfinity   byte = 0b110 << 5
 )
 
+// CompressionFlavor selects the meaning of the y-sign metadata bit of compressed points
+// (the bit distinguishing mCompressedSmallest from mCompressedLargest).
+//
+// Bytes and SetBytes use CompressionLexicographic; use BytesWithFlavor and SetBytesWithFlavor
+// to exchange compressed points with libraries using the y-parity convention.
+type CompressionFlavor uint8
+
+const (
+	// CompressionLexicographic sets the bit if and only if y is the lexicographically largest
+	// of the two square roots (ZCash / IETF convention). This is the default.
+	CompressionLexicographic CompressionFlavor = iota
+
+	// CompressionParity sets the bit if and only if y is odd, that is, if sgn0(y) = 1 as defined in the
+	// IETF hash-to-curve draft (parity of the first non-zero fp coordinate of y, in regular form).
+	CompressionParity
+)
+
 // SizeOfGT represents the size in bytes that a GT element need in binary form
 const SizeOfGT = fptower.SizeOfGT
 
@@ -333,6 +352,47 @@ func isCompressed(msb byte) bool {
 	return !((mData == mUncompressed) || (mData == mUncompressedInfinity))
 }
 
+// isInfinityAnyScheme returns true if buf is the encoding of the point at infinity in one of the
+// two metadata schemes: the all-zero buffer, or 0b010 or 0b110 in the 3 most significant bits
+// followed by zeros
+func isInfinityAnyScheme(buf []byte) bool {
+	switch buf[0] {
+	case 0b000 << 5, 0b010 << 5, 0b110 << 5:
+	default:
+		return false
+	}
+	for _, b := range buf[1:] {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isCanonical returns true if each fp.Bytes chunk of buf (metadata bits of buf[0] being ignored)
+// encodes an integer strictly smaller than the base field modulus
+func isCanonical(buf []byte) bool {
+	var chunk [fp.Bytes]byte
+	var e fp.Element
+	for i := 0; i+fp.Bytes <= len(buf); i += fp.Bytes {
+		copy(chunk[:], buf[i:i+fp.Bytes])
+		if i == 0 {
+			chunk[0] &= ^mMask
+		}
+		if e.SetBytesCanonical(chunk[:]) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// reverseBytes reverses b in place; it converts a big-endian encoding to little-endian and vice versa
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
 // NewEncoder returns a binary encoder supporting curve bls24-317 objects
 func NewEncoder(w io.Writer, options ...func(*Encoder)) *Encoder {
 	// default settings
@@ -706,6 +766,53 @@ func (p *G1Affine) RawBytes() (res [SizeOfG1AffineUncompressed]byte) {
 	return
 }
 
+// BothBytes returns both the compressed (see Bytes()) and uncompressed (see RawBytes())
+// binary representations of p, converting the coordinates from Montgomery form only once
+func (p *G1Affine) BothBytes() (compressed [SizeOfG1AffineCompressed]byte, uncompressed [SizeOfG1AffineUncompressed]byte) {
+	uncompressed = p.RawBytes()
+
+	// check if p is infinity point
+	if p.X.IsZero() && p.Y.IsZero() {
+		compressed[0] = mCompressedInfinity
+		return
+	}
+
+	// the X coordinate is stored identically in both representations
+	copy(compressed[:], uncompressed[:SizeOfG1AffineCompressed])
+	compressed[0] &= ^mMask
+
+	msbMask := mCompressedSmallest
+	// compressed, we need to know if Y is lexicographically bigger than -Y
+	// if p.Y ">" -p.Y
+	if p.Y.LexicographicallyLargest() {
+		msbMask = mCompressedLargest
+	}
+	compressed[0] |= msbMask
+
+	return
+}
+
+// MatchesCompressed returns true if buf[:SizeOfG1AffineCompressed] is the compressed encoding of p
+// (see Bytes()), that is, if it encodes the x-coordinate of p and the correct y-sign metadata.
+//
+// This is cheaper than decompressing buf and comparing the points since no square root is computed,
+// but it expects buf to be a canonical encoding.
+//
+// It returns io.ErrShortBuffer if buf is too short and an error if buf is not a compressed encoding.
+func (p *G1Affine) MatchesCompressed(buf []byte) (bool, error) {
+	if len(buf) < SizeOfG1AffineCompressed {
+		return false, io.ErrShortBuffer
+	}
+	if !isCompressed(buf[0]) {
+		return false, errors.New("invalid encoding: point is not compressed")
+	}
+
+	var encoded [SizeOfG1AffineCompressed]byte
+	copy(encoded[:], buf[:SizeOfG1AffineCompressed])
+
+	return encoded == p.Bytes(), nil
+}
+
 // SetBytes sets p from binary representation in buf and returns number of consumed bytes
 //
 // bytes in buf must match either RawBytes() or Bytes() output
@@ -720,6 +827,223 @@ func (p *G1Affine) SetBytes(buf []byte) (int, error) {
 	return p.setBytes(buf, true)
 }
 
+// SetBytesCanonical behaves like SetBytes but additionally rejects buf if one of the encoded
+// coordinates is not canonical, i.e. is not strictly smaller than the base field modulus.
+//
+// SetBytes reduces the coordinates modulo p, hence a given point has several valid encodings;
+// SetBytesCanonical must be used when encodings must be unique (e.g. consensus-critical applications).
+func (p *G1Affine) SetBytesCanonical(buf []byte) (int, error) {
+	if len(buf) < SizeOfG1AffineCompressed {
+		return 0, io.ErrShortBuffer
+	}
+	nbBytes := SizeOfG1AffineCompressed
+	if !isCompressed(buf[0]) {
+		nbBytes = SizeOfG1AffineUncompressed
+		if len(buf) < nbBytes {
+			return 0, io.ErrShortBuffer
+		}
+	}
+	if !isCanonical(buf[:nbBytes]) {
+		return 0, errors.New("invalid point: non-canonical coordinate encoding")
+	}
+	return p.setBytes(buf, true)
+}
+
+// BytesWithFlavor returns the compressed encoding of p, like Bytes, but with the y-sign metadata bit
+// following the given CompressionFlavor.
+func (p *G1Affine) BytesWithFlavor(flavor CompressionFlavor) (res [SizeOfG1AffineCompressed]byte) {
+	res = p.Bytes()
+	if flavor == CompressionParity && !p.IsInfinity() {
+		res[0] &= ^mMask
+		if p.ySgn0() {
+			res[0] |= mCompressedLargest
+		} else {
+			res[0] |= mCompressedSmallest
+		}
+	}
+	return
+}
+
+// SetBytesWithFlavor sets p from binary representation in buf and returns number of consumed bytes,
+// like SetBytes, but reads the y-sign metadata bit of a compressed buf following the given CompressionFlavor.
+func (p *G1Affine) SetBytesWithFlavor(buf []byte, flavor CompressionFlavor) (int, error) {
+	n, err := p.SetBytes(buf)
+	if err != nil || flavor != CompressionParity || !isCompressed(buf[0]) || p.IsInfinity() {
+		return n, err
+	}
+
+	// SetBytes picked the root of y² following the lexicographic convention,
+	// we negate it if its sign doesn't match the metadata bit
+	if p.ySgn0() != (buf[0]&mMask == mCompressedLargest) {
+		p.Y.Neg(&p.Y)
+	}
+	return n, nil
+}
+
+// SetBytesLenient behaves like SetBytes, but also accepts the encodings of the point at infinity
+// of both metadata schemes, for interoperability with libraries using the other one:
+//   - the all-zero buffer,
+//   - 0b010 followed by zeros (uncompressed infinity in the ZCash scheme, compressed infinity
+//     when fewer than 3 bits are available),
+//   - 0b110 followed by zeros (compressed infinity in the ZCash scheme).
+//
+// Unlike SetBytes, the whole buf is the encoding: infinity is recognized as such only if
+// len(buf) is SizeOfG1AffineCompressed or SizeOfG1AffineUncompressed, and SetBytesLenient
+// then returns len(buf). It can't be used to read points from a stream.
+func (p *G1Affine) SetBytesLenient(buf []byte) (int, error) {
+	if (len(buf) == SizeOfG1AffineCompressed || len(buf) == SizeOfG1AffineUncompressed) && isInfinityAnyScheme(buf) {
+		p.X.SetZero()
+		p.Y.SetZero()
+		return len(buf), nil
+	}
+	return p.setBytes(buf, true)
+}
+
+// ySgn0 returns true if sgn0(p.Y) = 1, i.e. if the first non-zero fp coordinate of p.Y is odd
+func (p *G1Affine) ySgn0() bool {
+	ys := [...]*fp.Element{&p.Y}
+	for _, y := range ys {
+		if !y.IsZero() {
+			tmp := *y
+			tmp.FromMont()
+			return tmp[0]&1 == 1
+		}
+	}
+	return false
+}
+
+// SetBytesUnsafe sets p from buf, the uncompressed encoding of a point as returned by RawBytes.
+//
+// WARNING: buf MUST come from a trusted source (e.g. data this program wrote itself with RawBytes).
+// Nothing is validated: the metadata bits are not read (except to detect the point at infinity),
+// the coordinates are neither reduced modulo p nor checked to be canonical, and the point is
+// checked neither to be on the curve nor to be in the prime order subgroup.
+// A malformed buf yields an invalid point; if len(buf) < SizeOfG1AffineUncompressed, SetBytesUnsafe panics.
+func (p *G1Affine) SetBytesUnsafe(buf []byte) {
+	_ = buf[SizeOfG1AffineUncompressed-1]
+	if buf[0] == mUncompressedInfinity {
+		p.X.SetZero()
+		p.Y.SetZero()
+		return
+	}
+
+	// read X and Y coordinates
+	p.X[4] = binary.BigEndian.Uint64(buf[0:8])
+	p.X[3] = binary.BigEndian.Uint64(buf[8:16])
+	p.X[2] = binary.BigEndian.Uint64(buf[16:24])
+	p.X[1] = binary.BigEndian.Uint64(buf[24:32])
+	p.X[0] = binary.BigEndian.Uint64(buf[32:40])
+	p.X.ToMont()
+
+	p.Y[4] = binary.BigEndian.Uint64(buf[40:48])
+	p.Y[3] = binary.BigEndian.Uint64(buf[48:56])
+	p.Y[2] = binary.BigEndian.Uint64(buf[56:64])
+	p.Y[1] = binary.BigEndian.Uint64(buf[64:72])
+	p.Y[0] = binary.BigEndian.Uint64(buf[72:80])
+	p.Y.ToMont()
+
+}
+
+// BytesLE returns the compressed encoding of p in little-endian byte order.
+//
+// It is Bytes() with its bytes reversed: the metadata (flag) bits are the most significant bits
+// of the last byte.
+func (p *G1Affine) BytesLE() (res [SizeOfG1AffineCompressed]byte) {
+	res = p.Bytes()
+	reverseBytes(res[:])
+	return
+}
+
+// RawBytesLE returns the uncompressed encoding of p in little-endian byte order.
+//
+// It is RawBytes() with the bytes of the X and Y coordinates reversed separately, such that
+// the encoding of X still comes first and the metadata bits are the most significant bits
+// of the last byte of X.
+func (p *G1Affine) RawBytesLE() (res [SizeOfG1AffineUncompressed]byte) {
+	res = p.RawBytes()
+	reverseBytes(res[:SizeOfG1AffineCompressed])
+	reverseBytes(res[SizeOfG1AffineCompressed:])
+	return
+}
+
+// SetBytesLE sets p from the little-endian representation in buf and returns the number of consumed bytes
+//
+// bytes in buf must match either RawBytesLE() or BytesLE() output; the checks are the same as in SetBytes.
+func (p *G1Affine) SetBytesLE(buf []byte) (int, error) {
+	if len(buf) < SizeOfG1AffineCompressed {
+		return 0, io.ErrShortBuffer
+	}
+
+	// convert buf to the big-endian encoding expected by SetBytes
+	var be [SizeOfG1AffineUncompressed]byte
+	copy(be[:SizeOfG1AffineCompressed], buf)
+	reverseBytes(be[:SizeOfG1AffineCompressed])
+
+	if isCompressed(be[0]) {
+		return p.setBytes(be[:SizeOfG1AffineCompressed], true)
+	}
+
+	if len(buf) < SizeOfG1AffineUncompressed {
+		return 0, io.ErrShortBuffer
+	}
+	copy(be[SizeOfG1AffineCompressed:], buf[SizeOfG1AffineCompressed:])
+	reverseBytes(be[SizeOfG1AffineCompressed:])
+
+	return p.setBytes(be[:], true)
+}
+
+// EncodeHex returns the hexadecimal encoding of the compressed representation of p (see Bytes())
+func (p *G1Affine) EncodeHex() string {
+	b := p.Bytes()
+	return hex.EncodeToString(b[:])
+}
+
+// DecodeHex sets p from s, the hexadecimal encoding of its compressed representation, as returned by EncodeHex
+//
+// s must encode exactly SizeOfG1AffineCompressed bytes; the checks are the same as in SetBytesCanonical.
+func (p *G1Affine) DecodeHex(s string) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return p.setCompressedCanonical(b)
+}
+
+// EncodeBech32 returns the bech32 encoding (BIP-173) of the compressed representation of p (see Bytes()),
+// with the human-readable prefix hrp
+//
+// hrp must be non empty, lower case and made of ASCII characters in the range [33, 126]. The encoded string
+// may exceed the 90 characters limit of BIP-173.
+func (p *G1Affine) EncodeBech32(hrp string) (string, error) {
+	b := p.Bytes()
+	return bech32.Encode(hrp, b[:])
+}
+
+// DecodeBech32 sets p from s, as returned by EncodeBech32, and returns its human-readable prefix
+//
+// It returns an error if the checksum of s is invalid. The decoded bytes must be exactly
+// SizeOfG1AffineCompressed long; the checks are the same as in SetBytesCanonical.
+// The caller is expected to check that the returned prefix is the one it expects.
+func (p *G1Affine) DecodeBech32(s string) (hrp string, err error) {
+	hrp, b, err := bech32.Decode(s)
+	if err != nil {
+		return "", err
+	}
+	if err = p.setCompressedCanonical(b); err != nil {
+		return "", err
+	}
+	return hrp, nil
+}
+
+// setCompressedCanonical sets p from buf, which must be exactly a canonical compressed encoding of a point
+func (p *G1Affine) setCompressedCanonical(buf []byte) error {
+	if len(buf) != SizeOfG1AffineCompressed || !isCompressed(buf[0]) {
+		return errors.New("invalid encoding: expected a compressed point")
+	}
+	_, err := p.SetBytesCanonical(buf)
+	return err
+}
+
 func (p *G1Affine) setBytes(buf []byte, subGroupCheck bool) (int, error) {
 	if len(buf) < SizeOfG1AffineCompressed {
 		return 0, io.ErrShortBuffer
@@ -1044,6 +1368,53 @@ func (p *G2Affine) RawBytes() (res [SizeOfG2AffineUncompressed]byte) {
 	return
 }
 
+// BothBytes returns both the compressed (see Bytes()) and uncompressed (see RawBytes())
+// binary representations of p, converting the coordinates from Montgomery form only once
+func (p *G2Affine) BothBytes() (compressed [SizeOfG2AffineCompressed]byte, uncompressed [SizeOfG2AffineUncompressed]byte) {
+	uncompressed = p.RawBytes()
+
+	// check if p is infinity point
+	if p.X.IsZero() && p.Y.IsZero() {
+		compressed[0] = mCompressedInfinity
+		return
+	}
+
+	// the X coordinate is stored identically in both representations
+	copy(compressed[:], uncompressed[:SizeOfG2AffineCompressed])
+	compressed[0] &= ^mMask
+
+	msbMask := mCompressedSmallest
+	// compressed, we need to know if Y is lexicographically bigger than -Y
+	// if p.Y ">" -p.Y
+	if p.Y.LexicographicallyLargest() {
+		msbMask = mCompressedLargest
+	}
+	compressed[0] |= msbMask
+
+	return
+}
+
+// MatchesCompressed returns true if buf[:SizeOfG2AffineCompressed] is the compressed encoding of p
+// (see Bytes()), that is, if it encodes the x-coordinate of p and the correct y-sign metadata.
+//
+// This is cheaper than decompressing buf and comparing the points since no square root is computed,
+// but it expects buf to be a canonical encoding.
+//
+// It returns io.ErrShortBuffer if buf is too short and an error if buf is not a compressed encoding.
+func (p *G2Affine) MatchesCompressed(buf []byte) (bool, error) {
+	if len(buf) < SizeOfG2AffineCompressed {
+		return false, io.ErrShortBuffer
+	}
+	if !isCompressed(buf[0]) {
+		return false, errors.New("invalid encoding: point is not compressed")
+	}
+
+	var encoded [SizeOfG2AffineCompressed]byte
+	copy(encoded[:], buf[:SizeOfG2AffineCompressed])
+
+	return encoded == p.Bytes(), nil
+}
+
 // SetBytes sets p from binary representation in buf and returns number of consumed bytes
 //
 // bytes in buf must match either RawBytes() or Bytes() output
@@ -1058,6 +1429,267 @@ func (p *G2Affine) SetBytes(buf []byte) (int, error) {
 	return p.setBytes(buf, true)
 }
 
+// SetBytesCanonical behaves like SetBytes but additionally rejects buf if one of the encoded
+// coordinates is not canonical, i.e. is not strictly smaller than the base field modulus.
+//
+// SetBytes reduces the coordinates modulo p, hence a given point has several valid encodings;
+// SetBytesCanonical must be used when encodings must be unique (e.g. consensus-critical applications).
+func (p *G2Affine) SetBytesCanonical(buf []byte) (int, error) {
+	if len(buf) < SizeOfG2AffineCompressed {
+		return 0, io.ErrShortBuffer
+	}
+	nbBytes := SizeOfG2AffineCompressed
+	if !isCompressed(buf[0]) {
+		nbBytes = SizeOfG2AffineUncompressed
+		if len(buf) < nbBytes {
+			return 0, io.ErrShortBuffer
+		}
+	}
+	if !isCanonical(buf[:nbBytes]) {
+		return 0, errors.New("invalid point: non-canonical coordinate encoding")
+	}
+	return p.setBytes(buf, true)
+}
+
+// BytesWithFlavor returns the compressed encoding of p, like Bytes, but with the y-sign metadata bit
+// following the given CompressionFlavor.
+func (p *G2Affine) BytesWithFlavor(flavor CompressionFlavor) (res [SizeOfG2AffineCompressed]byte) {
+	res = p.Bytes()
+	if flavor == CompressionParity && !p.IsInfinity() {
+		res[0] &= ^mMask
+		if p.ySgn0() {
+			res[0] |= mCompressedLargest
+		} else {
+			res[0] |= mCompressedSmallest
+		}
+	}
+	return
+}
+
+// SetBytesWithFlavor sets p from binary representation in buf and returns number of consumed bytes,
+// like SetBytes, but reads the y-sign metadata bit of a compressed buf following the given CompressionFlavor.
+func (p *G2Affine) SetBytesWithFlavor(buf []byte, flavor CompressionFlavor) (int, error) {
+	n, err := p.SetBytes(buf)
+	if err != nil || flavor != CompressionParity || !isCompressed(buf[0]) || p.IsInfinity() {
+		return n, err
+	}
+
+	// SetBytes picked the root of y² following the lexicographic convention,
+	// we negate it if its sign doesn't match the metadata bit
+	if p.ySgn0() != (buf[0]&mMask == mCompressedLargest) {
+		p.Y.Neg(&p.Y)
+	}
+	return n, nil
+}
+
+// SetBytesLenient behaves like SetBytes, but also accepts the encodings of the point at infinity
+// of both metadata schemes, for interoperability with libraries using the other one:
+//   - the all-zero buffer,
+//   - 0b010 followed by zeros (uncompressed infinity in the ZCash scheme, compressed infinity
+//     when fewer than 3 bits are available),
+//   - 0b110 followed by zeros (compressed infinity in the ZCash scheme).
+//
+// Unlike SetBytes, the whole buf is the encoding: infinity is recognized as such only if
+// len(buf) is SizeOfG2AffineCompressed or SizeOfG2AffineUncompressed, and SetBytesLenient
+// then returns len(buf). It can't be used to read points from a stream.
+func (p *G2Affine) SetBytesLenient(buf []byte) (int, error) {
+	if (len(buf) == SizeOfG2AffineCompressed || len(buf) == SizeOfG2AffineUncompressed) && isInfinityAnyScheme(buf) {
+		p.X.SetZero()
+		p.Y.SetZero()
+		return len(buf), nil
+	}
+	return p.setBytes(buf, true)
+}
+
+// ySgn0 returns true if sgn0(p.Y) = 1, i.e. if the first non-zero fp coordinate of p.Y is odd
+func (p *G2Affine) ySgn0() bool {
+	ys := [...]*fp.Element{&p.Y.B0.A0, &p.Y.B0.A1, &p.Y.B1.A0, &p.Y.B1.A1}
+	for _, y := range ys {
+		if !y.IsZero() {
+			tmp := *y
+			tmp.FromMont()
+			return tmp[0]&1 == 1
+		}
+	}
+	return false
+}
+
+// SetBytesUnsafe sets p from buf, the uncompressed encoding of a point as returned by RawBytes.
+//
+// WARNING: buf MUST come from a trusted source (e.g. data this program wrote itself with RawBytes).
+// Nothing is validated: the metadata bits are not read (except to detect the point at infinity),
+// the coordinates are neither reduced modulo p nor checked to be canonical, and the point is
+// checked neither to be on the curve nor to be in the prime order subgroup.
+// A malformed buf yields an invalid point; if len(buf) < SizeOfG2AffineUncompressed, SetBytesUnsafe panics.
+func (p *G2Affine) SetBytesUnsafe(buf []byte) {
+	_ = buf[SizeOfG2AffineUncompressed-1]
+	if buf[0] == mUncompressedInfinity {
+		p.X.SetZero()
+		p.Y.SetZero()
+		return
+	}
+
+	// read X and Y coordinates
+	// p.X.B1.A1 | p.X.B1.A0 | p.X.B0.A1 | p.X.B0.A0
+	p.X.B1.A1[4] = binary.BigEndian.Uint64(buf[0:8])
+	p.X.B1.A1[3] = binary.BigEndian.Uint64(buf[8:16])
+	p.X.B1.A1[2] = binary.BigEndian.Uint64(buf[16:24])
+	p.X.B1.A1[1] = binary.BigEndian.Uint64(buf[24:32])
+	p.X.B1.A1[0] = binary.BigEndian.Uint64(buf[32:40])
+	p.X.B1.A1.ToMont()
+
+	p.X.B1.A0[4] = binary.BigEndian.Uint64(buf[40:48])
+	p.X.B1.A0[3] = binary.BigEndian.Uint64(buf[48:56])
+	p.X.B1.A0[2] = binary.BigEndian.Uint64(buf[56:64])
+	p.X.B1.A0[1] = binary.BigEndian.Uint64(buf[64:72])
+	p.X.B1.A0[0] = binary.BigEndian.Uint64(buf[72:80])
+	p.X.B1.A0.ToMont()
+
+	p.X.B0.A1[4] = binary.BigEndian.Uint64(buf[80:88])
+	p.X.B0.A1[3] = binary.BigEndian.Uint64(buf[88:96])
+	p.X.B0.A1[2] = binary.BigEndian.Uint64(buf[96:104])
+	p.X.B0.A1[1] = binary.BigEndian.Uint64(buf[104:112])
+	p.X.B0.A1[0] = binary.BigEndian.Uint64(buf[112:120])
+	p.X.B0.A1.ToMont()
+
+	p.X.B0.A0[4] = binary.BigEndian.Uint64(buf[120:128])
+	p.X.B0.A0[3] = binary.BigEndian.Uint64(buf[128:136])
+	p.X.B0.A0[2] = binary.BigEndian.Uint64(buf[136:144])
+	p.X.B0.A0[1] = binary.BigEndian.Uint64(buf[144:152])
+	p.X.B0.A0[0] = binary.BigEndian.Uint64(buf[152:160])
+	p.X.B0.A0.ToMont()
+
+	// p.Y.B1.A1 | p.Y.B1.A0 | p.Y.B0.A1 | p.Y.B0.A0
+	p.Y.B1.A1[4] = binary.BigEndian.Uint64(buf[160:168])
+	p.Y.B1.A1[3] = binary.BigEndian.Uint64(buf[168:176])
+	p.Y.B1.A1[2] = binary.BigEndian.Uint64(buf[176:184])
+	p.Y.B1.A1[1] = binary.BigEndian.Uint64(buf[184:192])
+	p.Y.B1.A1[0] = binary.BigEndian.Uint64(buf[192:200])
+	p.Y.B1.A1.ToMont()
+
+	p.Y.B1.A0[4] = binary.BigEndian.Uint64(buf[200:208])
+	p.Y.B1.A0[3] = binary.BigEndian.Uint64(buf[208:216])
+	p.Y.B1.A0[2] = binary.BigEndian.Uint64(buf[216:224])
+	p.Y.B1.A0[1] = binary.BigEndian.Uint64(buf[224:232])
+	p.Y.B1.A0[0] = binary.BigEndian.Uint64(buf[232:240])
+	p.Y.B1.A0.ToMont()
+
+	p.Y.B0.A1[4] = binary.BigEndian.Uint64(buf[240:248])
+	p.Y.B0.A1[3] = binary.BigEndian.Uint64(buf[248:256])
+	p.Y.B0.A1[2] = binary.BigEndian.Uint64(buf[256:264])
+	p.Y.B0.A1[1] = binary.BigEndian.Uint64(buf[264:272])
+	p.Y.B0.A1[0] = binary.BigEndian.Uint64(buf[272:280])
+	p.Y.B0.A1.ToMont()
+
+	p.Y.B0.A0[4] = binary.BigEndian.Uint64(buf[280:288])
+	p.Y.B0.A0[3] = binary.BigEndian.Uint64(buf[288:296])
+	p.Y.B0.A0[2] = binary.BigEndian.Uint64(buf[296:304])
+	p.Y.B0.A0[1] = binary.BigEndian.Uint64(buf[304:312])
+	p.Y.B0.A0[0] = binary.BigEndian.Uint64(buf[312:320])
+	p.Y.B0.A0.ToMont()
+
+}
+
+// BytesLE returns the compressed encoding of p in little-endian byte order.
+//
+// It is Bytes() with its bytes reversed: the metadata (flag) bits are the most significant bits
+// of the last byte.
+func (p *G2Affine) BytesLE() (res [SizeOfG2AffineCompressed]byte) {
+	res = p.Bytes()
+	reverseBytes(res[:])
+	return
+}
+
+// RawBytesLE returns the uncompressed encoding of p in little-endian byte order.
+//
+// It is RawBytes() with the bytes of the X and Y coordinates reversed separately, such that
+// the encoding of X still comes first and the metadata bits are the most significant bits
+// of the last byte of X.
+func (p *G2Affine) RawBytesLE() (res [SizeOfG2AffineUncompressed]byte) {
+	res = p.RawBytes()
+	reverseBytes(res[:SizeOfG2AffineCompressed])
+	reverseBytes(res[SizeOfG2AffineCompressed:])
+	return
+}
+
+// SetBytesLE sets p from the little-endian representation in buf and returns the number of consumed bytes
+//
+// bytes in buf must match either RawBytesLE() or BytesLE() output; the checks are the same as in SetBytes.
+func (p *G2Affine) SetBytesLE(buf []byte) (int, error) {
+	if len(buf) < SizeOfG2AffineCompressed {
+		return 0, io.ErrShortBuffer
+	}
+
+	// convert buf to the big-endian encoding expected by SetBytes
+	var be [SizeOfG2AffineUncompressed]byte
+	copy(be[:SizeOfG2AffineCompressed], buf)
+	reverseBytes(be[:SizeOfG2AffineCompressed])
+
+	if isCompressed(be[0]) {
+		return p.setBytes(be[:SizeOfG2AffineCompressed], true)
+	}
+
+	if len(buf) < SizeOfG2AffineUncompressed {
+		return 0, io.ErrShortBuffer
+	}
+	copy(be[SizeOfG2AffineCompressed:], buf[SizeOfG2AffineCompressed:])
+	reverseBytes(be[SizeOfG2AffineCompressed:])
+
+	return p.setBytes(be[:], true)
+}
+
+// EncodeHex returns the hexadecimal encoding of the compressed representation of p (see Bytes())
+func (p *G2Affine) EncodeHex() string {
+	b := p.Bytes()
+	return hex.EncodeToString(b[:])
+}
+
+// DecodeHex sets p from s, the hexadecimal encoding of its compressed representation, as returned by EncodeHex
+//
+// s must encode exactly SizeOfG2AffineCompressed bytes; the checks are the same as in SetBytesCanonical.
+func (p *G2Affine) DecodeHex(s string) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return p.setCompressedCanonical(b)
+}
+
+// EncodeBech32 returns the bech32 encoding (BIP-173) of the compressed representation of p (see Bytes()),
+// with the human-readable prefix hrp
+//
+// hrp must be non empty, lower case and made of ASCII characters in the range [33, 126]. The encoded string
+// may exceed the 90 characters limit of BIP-173.
+func (p *G2Affine) EncodeBech32(hrp string) (string, error) {
+	b := p.Bytes()
+	return bech32.Encode(hrp, b[:])
+}
+
+// DecodeBech32 sets p from s, as returned by EncodeBech32, and returns its human-readable prefix
+//
+// It returns an error if the checksum of s is invalid. The decoded bytes must be exactly
+// SizeOfG2AffineCompressed long; the checks are the same as in SetBytesCanonical.
+// The caller is expected to check that the returned prefix is the one it expects.
+func (p *G2Affine) DecodeBech32(s string) (hrp string, err error) {
+	hrp, b, err := bech32.Decode(s)
+	if err != nil {
+		return "", err
+	}
+	if err = p.setCompressedCanonical(b); err != nil {
+		return "", err
+	}
+	return hrp, nil
+}
+
+// setCompressedCanonical sets p from buf, which must be exactly a canonical compressed encoding of a point
+func (p *G2Affine) setCompressedCanonical(buf []byte) error {
+	if len(buf) != SizeOfG2AffineCompressed || !isCompressed(buf[0]) {
+		return errors.New("invalid encoding: expected a compressed point")
+	}
+	_, err := p.SetBytesCanonical(buf)
+	return err
+}
+
 func (p *G2Affine) setBytes(buf []byte, subGroupCheck bool) (int, error) {
 	if len(buf) < SizeOfG2AffineCompressed {
 		return 0, io.ErrShortBuffer
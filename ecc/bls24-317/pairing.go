@@ -18,6 +18,7 @@ import (
 	"errors"
 
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/internal/fptower"
+	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
 // GT target group of the pairing
@@ -32,6 +33,12 @@ type lineEvaluation struct {
 // Pair calculates the reduced pairing for a set of points
 // ∏ᵢ e(Pᵢ, Qᵢ).
 //
+// The Miller loop is the optimal Ate one, which is the only variant implemented
+// for bls24-317; see PairOptimalAte.
+//
+// If Pᵢ or Qᵢ is the point at infinity, e(Pᵢ, Qᵢ) = 1; in particular Pair returns
+// the identity of GT if all pairs contain the point at infinity.
+//
 // This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
 func Pair(P []G1Affine, Q []G2Affine) (GT, error) {
 	f, err := MillerLoop(P, Q)
@@ -41,6 +48,16 @@ func Pair(P []G1Affine, Q []G2Affine) (GT, error) {
 	return FinalExponentiation(&f), nil
 }
 
+// PairOptimalAte calculates the reduced optimal Ate pairing for a set of points
+// ∏ᵢ e(Pᵢ, Qᵢ).
+//
+// It is the default pairing of bls24-317, that is, Pair is equivalent to PairOptimalAte.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairOptimalAte(P []G1Affine, Q []G2Affine) (GT, error) {
+	return Pair(P, Q)
+}
+
 // PairingCheck calculates the reduced pairing for a set of points and returns True if the result is One
 // ∏ᵢ e(Pᵢ, Qᵢ) =? 1
 //
@@ -55,6 +72,86 @@ func PairingCheck(P []G1Affine, Q []G2Affine) (bool, error) {
 	return f.Equal(&one), nil
 }
 
+// PairingEquality returns true if e(a, b) = e(c, d).
+//
+// It checks e(a, b) ⋅ e(-c, d) = 1 instead, which shares a single Miller loop and
+// a single final exponentiation between the two pairings.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairingEquality(a G1Affine, b G2Affine, c G1Affine, d G2Affine) bool {
+	ok, _ := PairingProductEquality([]G1Affine{a}, []G2Affine{b}, []G1Affine{c}, []G2Affine{d})
+	return ok
+}
+
+// PairingProductEquality returns true if ∏ᵢ e(Pᵢ, Qᵢ) = ∏ⱼ e(Rⱼ, Sⱼ).
+//
+// It checks ∏ᵢ e(Pᵢ, Qᵢ) ⋅ ∏ⱼ e(-Rⱼ, Sⱼ) = 1 instead, with a single (multi) Miller loop
+// and a single final exponentiation. See PairingCheck.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairingProductEquality(P []G1Affine, Q []G2Affine, R []G1Affine, S []G2Affine) (bool, error) {
+	if len(P) != len(Q) || len(R) != len(S) {
+		return false, errors.New("invalid inputs sizes")
+	}
+
+	g1 := make([]G1Affine, len(P)+len(R))
+	g2 := make([]G2Affine, len(Q)+len(S))
+	copy(g1, P)
+	copy(g2, Q)
+	copy(g2[len(Q):], S)
+	for j := range R {
+		g1[len(P)+j].Neg(&R[j])
+	}
+
+	return PairingCheck(g1, g2)
+}
+
+// PairBatch calculates the reduced pairings e(Pᵢ, Qᵢ) of independent pairs, in parallel.
+//
+// Unlike Pair, which returns the product ∏ᵢ e(Pᵢ, Qᵢ), it returns one pairing per pair:
+// the i-th result is e(Pᵢ, Qᵢ).
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairBatch(P []G1Affine, Q []G2Affine) ([]GT, error) {
+	if len(P) != len(Q) {
+		return nil, errors.New("invalid inputs sizes")
+	}
+	res := make([]GT, len(P))
+	parallel.Execute(len(P), func(start, end int) {
+		for i := start; i < end; i++ {
+			// a single pair has consistent sizes, so Pair can't fail
+			res[i], _ = Pair(P[i:i+1], Q[i:i+1])
+		}
+	})
+	return res, nil
+}
+
+// AccumulateMillerLoop multiplies acc by the Miller loop of the pairs (P[i], Q[i]), without
+// the final exponentiation, and returns acc.
+//
+// It lets accumulation schemes defer the final exponentiation: acc must be initialized
+// to 1 (acc.SetOne()), and after accumulating any number of sets of pairs,
+// FinalizeAccumulator(acc) is equivalent to a single PairingCheck on all the pairs.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func AccumulateMillerLoop(acc *GT, P []G1Affine, Q []G2Affine) (*GT, error) {
+	f, err := MillerLoop(P, Q)
+	if err != nil {
+		return acc, err
+	}
+	return acc.Mul(acc, &f), nil
+}
+
+// FinalizeAccumulator performs the final exponentiation of a product of Miller loops accumulated
+// with AccumulateMillerLoop, and returns true if the result is 1, i.e. if the product of all
+// the accumulated pairings is 1. acc is left unchanged.
+func FinalizeAccumulator(acc *GT) bool {
+	f := FinalExponentiation(acc)
+	var one GT
+	one.SetOne()
+	return f.Equal(&one)
+}
+
 // FinalExponentiation computes the exponentiation (∏ᵢ zᵢ)ᵈ
 // where d = (p²⁴-1)/r = (p²⁴-1)/Φ₂₄(p) ⋅ Φ₂₄(p)/r = (p¹²-1)(p⁴+1)(p⁸ - p⁴ +1)/r
 // we use instead d=s ⋅ (p¹²-1)(p⁴+1)(p⁸ - p⁴ +1)/r
@@ -113,6 +210,8 @@ func FinalExponentiation(z *GT, _z ...*GT) GT {
 
 // MillerLoop computes the multi-Miller loop
 // ∏ᵢ MillerLoop(Pᵢ, Qᵢ)
+//
+// Pairs where Pᵢ or Qᵢ is the point at infinity are skipped, as e(O, Qᵢ) = e(Pᵢ, O) = 1.
 func MillerLoop(P []G1Affine, Q []G2Affine) (GT, error) {
 	// check input size match
 	n := len(P)
@@ -187,6 +286,299 @@ func MillerLoop(P []G1Affine, Q []G2Affine) (GT, error) {
 	return result, nil
 }
 
+// MillerLoopLines computes the Miller loop of a single pair (P, Q), as MillerLoop, and also
+// returns the lines evaluated at P.
+//
+// lines[i] is the product of the lines evaluated at P during the i-th iteration of the loop
+// (the doubling line and, if any, the addition line), so that the Miller loop output f is
+// obtained by f ← 1, then f ← f²⋅lines[i] for each i.
+//
+// If P or Q is the point at infinity, lines is empty and f = 1.
+func MillerLoopLines(P G1Affine, Q G2Affine) ([]GT, GT) {
+	var f GT
+	f.SetOne()
+	if P.IsInfinity() || Q.IsInfinity() {
+		return nil, f
+	}
+
+	var qProj g2Proj
+	qProj.FromAffine(&Q)
+	var qNeg G2Affine
+	qNeg.Neg(&Q)
+
+	var l lineEvaluation
+	// evalLine multiplies z by the line l evaluated at P
+	evalLine := func(z *GT) {
+		l.r1.MulByElement(&l.r1, &P.X)
+		l.r2.MulByElement(&l.r2, &P.Y)
+		z.MulBy014(&l.r0, &l.r1, &l.r2)
+	}
+
+	lines := make([]GT, len(loopCounter)-1)
+
+	// i = len(loopCounter) - 2
+	lines[0].SetOne()
+	qProj.DoubleStep(&l)
+	evalLine(&lines[0])
+	f.Set(&lines[0])
+
+	for i := len(loopCounter) - 3; i >= 0; i-- {
+		line := &lines[len(loopCounter)-2-i]
+		line.SetOne()
+		qProj.DoubleStep(&l)
+		evalLine(line)
+		if loopCounter[i] == 1 {
+			qProj.AddMixedStep(&l, &Q)
+			evalLine(line)
+		} else if loopCounter[i] == -1 {
+			qProj.AddMixedStep(&l, &qNeg)
+			evalLine(line)
+		}
+		// (∏ᵢfᵢ)² × ℓ
+		f.Square(&f).Mul(&f, line)
+	}
+
+	return lines, f
+}
+
+// NbMillerLoopIterations is the number of iterations of the Miller loop,
+// i.e. the largest checkpoint accepted by MillerLoopSegments.
+const NbMillerLoopIterations = len(loopCounter) - 1
+
+// MillerLoopState is a checkpoint of the Miller loop of pairs (P, Q), as returned by MillerLoopSegments.
+//
+// It holds the Miller function accumulated so far and the current multiples of the Q,
+// so that the loop can be resumed from it, e.g. to bound the depth of a recursive
+// pairing computation by splitting the Miller loop in segments.
+type MillerLoopState struct {
+	// Iteration is the number of iterations of the loop already computed
+	Iteration int
+
+	// F is the Miller function accumulated so far
+	F GT
+
+	p     []G1Affine
+	q     []G2Affine
+	qNeg  []G2Affine
+	qProj []g2Proj
+}
+
+// NewMillerLoopState returns the state of the Miller loop of the pairs (P[i], Q[i])
+// before its first iteration.
+func NewMillerLoopState(P []G1Affine, Q []G2Affine) (MillerLoopState, error) {
+	// check input size match
+	n := len(P)
+	if n == 0 || n != len(Q) {
+		return MillerLoopState{}, errors.New("invalid inputs sizes")
+	}
+
+	// filter infinity points
+	s := MillerLoopState{
+		p: make([]G1Affine, 0, n),
+		q: make([]G2Affine, 0, n),
+	}
+	for k := 0; k < n; k++ {
+		if P[k].IsInfinity() || Q[k].IsInfinity() {
+			continue
+		}
+		s.p = append(s.p, P[k])
+		s.q = append(s.q, Q[k])
+	}
+
+	// projective points for Q
+	s.qProj = make([]g2Proj, len(s.q))
+	s.qNeg = make([]G2Affine, len(s.q))
+	for k := 0; k < len(s.q); k++ {
+		s.qProj[k].FromAffine(&s.q[k])
+		s.qNeg[k].Neg(&s.q[k])
+	}
+	s.F.SetOne()
+
+	return s, nil
+}
+
+// MillerLoopSegments computes the Miller loop of the pairs (P[i], Q[i]), as MillerLoop,
+// and returns its state after each of the given numbers of iterations.
+//
+// checkpoints must be non-decreasing and in [0, NbMillerLoopIterations]. The returned states
+// don't share memory, and completing any of them gives the output of MillerLoop.
+func MillerLoopSegments(P []G1Affine, Q []G2Affine, checkpoints []int) ([]MillerLoopState, error) {
+	s, err := NewMillerLoopState(P, Q)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]MillerLoopState, len(checkpoints))
+	for i, c := range checkpoints {
+		if c < s.Iteration || c > NbMillerLoopIterations {
+			return nil, errors.New("checkpoints should be non-decreasing and at most NbMillerLoopIterations")
+		}
+		s.run(c - s.Iteration)
+		states[i] = s.clone()
+	}
+
+	return states, nil
+}
+
+// Resume returns the state of the Miller loop after up to n more iterations.
+// s is left unchanged.
+func (s *MillerLoopState) Resume(n int) MillerLoopState {
+	res := s.clone()
+	res.run(n)
+	return res
+}
+
+// Complete computes the remaining iterations of the Miller loop and returns its output,
+// as MillerLoop. s is left unchanged.
+func (s *MillerLoopState) Complete() GT {
+	res := s.Resume(NbMillerLoopIterations - s.Iteration)
+
+	return res.F
+}
+
+// run computes up to n more iterations of the Miller loop
+func (s *MillerLoopState) run(n int) {
+	for ; n > 0 && s.Iteration < NbMillerLoopIterations; n-- {
+		s.step()
+	}
+}
+
+// step computes the next iteration of the Miller loop
+func (s *MillerLoopState) step() {
+	i := len(loopCounter) - 2 - s.Iteration
+	s.Iteration++
+
+	var l lineEvaluation
+
+	// (∏ᵢfᵢ)²
+	s.F.Square(&s.F)
+
+	for k := 0; k < len(s.p); k++ {
+		s.qProj[k].DoubleStep(&l)
+		// line evaluation
+		l.r1.MulByElement(&l.r1, &s.p[k].X)
+		l.r2.MulByElement(&l.r2, &s.p[k].Y)
+		s.F.MulBy014(&l.r0, &l.r1, &l.r2)
+	}
+
+	if loopCounter[i] == 0 {
+		return
+	}
+	for k := 0; k < len(s.p); k++ {
+		if loopCounter[i] == 1 {
+			s.qProj[k].AddMixedStep(&l, &s.q[k])
+		} else {
+			s.qProj[k].AddMixedStep(&l, &s.qNeg[k])
+		}
+		// line evaluation
+		l.r1.MulByElement(&l.r1, &s.p[k].X)
+		l.r2.MulByElement(&l.r2, &s.p[k].Y)
+		s.F.MulBy014(&l.r0, &l.r1, &l.r2)
+	}
+}
+
+// clone returns a deep copy of s
+func (s *MillerLoopState) clone() MillerLoopState {
+	res := MillerLoopState{
+		Iteration: s.Iteration,
+		F:         s.F,
+		p:         s.p,
+		q:         s.q,
+		qNeg:      s.qNeg,
+		qProj:     make([]g2Proj, len(s.qProj)),
+	}
+	copy(res.qProj, s.qProj)
+	return res
+}
+
+// PrecomputedLines holds the lines of the Miller loop of a point Q ∈ G2.
+//
+// The lines only depend on Q, the G1 argument of the pairing being plugged in when
+// evaluating them. Computing them once with PrecomputeLines and calling MillerLoopFixedQ
+// saves the G2 arithmetic when Q is shared by several pairings, e.g. e(P, Q) and e(P', Q)
+// for P' derived from P.
+type PrecomputedLines struct {
+	lines []lineEvaluation
+}
+
+// PrecomputeLines computes the lines of the Miller loop of Q, as MillerLoop would:
+// for each iteration, the doubling line and, if any, the addition line.
+//
+// If Q is the point at infinity, there are no lines.
+func PrecomputeLines(Q G2Affine) PrecomputedLines {
+	var res PrecomputedLines
+	if Q.IsInfinity() {
+		return res
+	}
+
+	var qProj g2Proj
+	qProj.FromAffine(&Q)
+	var qNeg G2Affine
+	qNeg.Neg(&Q)
+
+	var l lineEvaluation
+	res.lines = make([]lineEvaluation, 0, 2*len(loopCounter))
+	for i := len(loopCounter) - 2; i >= 0; i-- {
+		qProj.DoubleStep(&l)
+		res.lines = append(res.lines, l)
+		if loopCounter[i] == 1 {
+			qProj.AddMixedStep(&l, &Q)
+			res.lines = append(res.lines, l)
+		} else if loopCounter[i] == -1 {
+			qProj.AddMixedStep(&l, &qNeg)
+			res.lines = append(res.lines, l)
+		}
+	}
+
+	return res
+}
+
+// MillerLoopFixedQ computes the Miller loop of the pairs (P[i], Q[i]), as MillerLoop,
+// where lines[i] = PrecomputeLines(Q[i]).
+func MillerLoopFixedQ(P []G1Affine, lines []PrecomputedLines) (GT, error) {
+	// check input size match
+	n := len(P)
+	if n == 0 || n != len(lines) {
+		return GT{}, errors.New("invalid inputs sizes")
+	}
+
+	var result GT
+	result.SetOne()
+
+	// j is the index of the current line, the same for all Q
+	j := 0
+	for i := len(loopCounter) - 2; i >= 0; i-- {
+		// (∏ᵢfᵢ)²
+		result.Square(&result)
+
+		for k := 0; k < n; k++ {
+			// skip infinity points
+			if P[k].IsInfinity() || len(lines[k].lines) == 0 {
+				continue
+			}
+			mulByLine(&result, &lines[k].lines[j], &P[k])
+			if loopCounter[i] != 0 {
+				mulByLine(&result, &lines[k].lines[j+1], &P[k])
+			}
+		}
+
+		j++
+		if loopCounter[i] != 0 {
+			j++
+		}
+	}
+
+	return result, nil
+}
+
+// mulByLine multiplies z by the line l evaluated at P
+func mulByLine(z *GT, l *lineEvaluation, P *G1Affine) {
+	var r1, r2 fptower.E4
+	r1.MulByElement(&l.r1, &P.X)
+	r2.MulByElement(&l.r2, &P.Y)
+	z.MulBy014(&l.r0, &r1, &r2)
+}
+
 // DoubleStep doubles a point in Homogenous projective coordinates, and evaluates the line in Miller loop
 // https://eprint.iacr.org/2013/722.pdf (Section 4.3)
 func (p *g2Proj) DoubleStep(evaluations *lineEvaluation) {
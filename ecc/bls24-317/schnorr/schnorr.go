@@ -0,0 +1,234 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package schnorr
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+var (
+	ErrZeroKey   = errors.New("invalid private key: zero scalar")
+	ErrZeroNonce = errors.New("invalid nonce: zero scalar")
+)
+
+// domain separation tags of the keys, nonces and challenges, passed as first part to fr.HashToScalar
+//
+// Each challenge variant has its own nonce tag: signing a message under both variants with
+// the same nonce k would give two challenges c₁ ≠ c₂, and reveal x = (s₁-s₂)/(c₁-c₂).
+var (
+	keyDST              = []byte("gnark-crypto-schnorr-bls24-317-key")
+	nonceDST            = []byte("gnark-crypto-schnorr-bls24-317-nonce")
+	nonceNoKeyPrefixDST = []byte("gnark-crypto-schnorr-bls24-317-nonce-no-key-prefix")
+	challengeDST        = []byte("gnark-crypto-schnorr-bls24-317-challenge")
+)
+
+var g1GenAff bls24317.G1Affine
+var g1Gen bls24317.G1Jac
+
+func init() {
+	g1Gen, _, g1GenAff, _ = bls24317.Generators()
+}
+
+// Config holds the options of Sign and Verify. The zero value is the default.
+type Config struct {
+	// NoKeyPrefix computes the challenge as H(R, m) instead of H(R, P, m).
+	//
+	// Prefixing the challenge with the public key binds the signature to it, which prevents
+	// related-key attacks (e.g. on keys derived additively from a master key). It should only
+	// be disabled for compatibility with protocols that don't use it.
+	NoKeyPrefix bool
+}
+
+// PrivateKey of a Schnorr instance
+type PrivateKey struct {
+	PublicKey bls24317.G1Affine // P = x ⋅ G₁
+	scalar    fr.Element        // x
+}
+
+// Signature represents a Schnorr signature (R, s), with s ⋅ G₁ = R + c ⋅ P
+type Signature struct {
+	R bls24317.G1Affine
+	S fr.Element
+}
+
+// GenerateKey generates a private key (and its public key) from 32 bytes read from r.
+func GenerateKey(r io.Reader) (*PrivateKey, error) {
+	var seed [32]byte
+	if _, err := io.ReadFull(r, seed[:]); err != nil {
+		return nil, err
+	}
+	var priv PrivateKey
+	priv.scalar = fr.HashToScalar(keyDST, seed[:])
+	if priv.scalar.IsZero() {
+		return nil, ErrZeroKey
+	}
+	var x big.Int
+	priv.scalar.ToBigIntRegular(&x)
+	priv.PublicKey.ScalarMultiplication(&g1GenAff, &x)
+	return &priv, nil
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() bls24317.G1Affine {
+	return privKey.PublicKey
+}
+
+// Sign signs message with privKey.
+//
+// The nonce k is derived deterministically from the private key, the public key, the message
+// and the challenge variant selected by config, so that signing the same message twice gives the
+// same signature and no randomness is needed.
+func (privKey *PrivateKey) Sign(message []byte, config ...Config) (Signature, error) {
+	var sig Signature
+
+	dst := nonceDST
+	if noKeyPrefix(config) {
+		dst = nonceNoKeyPrefixDST
+	}
+	xb := privKey.scalar.Bytes()
+	pb := privKey.PublicKey.Bytes()
+	k := fr.HashToScalar(dst, xb[:], pb[:], message)
+	if k.IsZero() {
+		return sig, ErrZeroNonce
+	}
+
+	// R = k ⋅ G₁
+	var kBig big.Int
+	k.ToBigIntRegular(&kBig)
+	sig.R.ScalarMultiplication(&g1GenAff, &kBig)
+
+	// s = k + c ⋅ x
+	c := challenge(&sig.R, &privKey.PublicKey, message, config)
+	sig.S.Mul(&c, &privKey.scalar).Add(&sig.S, &k)
+
+	return sig, nil
+}
+
+// Verify returns true if sig is a valid signature of message under the public key pub,
+// that is if s ⋅ G₁ = R + c ⋅ P.
+//
+// It returns false if pub is the point at infinity, or if pub or R is not in G1.
+// config must match the one used by Sign.
+func Verify(pub *bls24317.G1Affine, message []byte, sig *Signature, config ...Config) bool {
+	if pub.IsInfinity() || !pub.IsOnCurve() || !pub.IsInSubGroup() {
+		return false
+	}
+	if !sig.R.IsOnCurve() || !sig.R.IsInSubGroup() {
+		return false
+	}
+
+	c := challenge(&sig.R, pub, message, config)
+
+	// s ⋅ G₁ - c ⋅ P
+	var s, cBig big.Int
+	sig.S.ToBigIntRegular(&s)
+	c.ToBigIntRegular(&cBig)
+	var lhs, cP, R bls24317.G1Jac
+	lhs.ScalarMultiplication(&g1Gen, &s)
+	cP.ScalarMultiplicationAffine(pub, &cBig)
+	lhs.SubAssign(&cP)
+
+	R.FromAffine(&sig.R)
+	return lhs.Equal(&R)
+}
+
+// BatchVerify verifies the signatures sigs[i] of msgs[i] under the public keys pubs[i].
+//
+// It checks a random linear combination of the verification equations,
+// ∑ ρᵢ ⋅ sᵢ ⋅ G₁ - ∑ ρᵢ ⋅ Rᵢ - ∑ ρᵢ ⋅ cᵢ ⋅ Pᵢ = ∞, with a single multi-exponentiation.
+// If it fails, the signatures are verified one by one to return the indices of the invalid ones,
+// so a batch with invalid signatures costs more than verifying them individually.
+//
+// It returns false and no index if the slices don't have the same length.
+// config must match the one used by Sign.
+func BatchVerify(pubs []bls24317.G1Affine, msgs [][]byte, sigs []Signature, config ...Config) (bool, []int) {
+	n := len(pubs)
+	if len(msgs) != n || len(sigs) != n {
+		return false, nil
+	}
+	if n == 0 {
+		return true, nil
+	}
+
+	// the combination only holds for points in G1, invalid points are handled by the second pass
+	batchOK := true
+	for i := 0; i < n && batchOK; i++ {
+		batchOK = !pubs[i].IsInfinity() && pubs[i].IsOnCurve() && pubs[i].IsInSubGroup() &&
+			sigs[i].R.IsOnCurve() && sigs[i].R.IsInSubGroup()
+	}
+
+	if batchOK {
+		// points = [G₁, R₀, …, Rₙ₋₁, P₀, …, Pₙ₋₁], scalars = [∑ ρᵢ ⋅ sᵢ, -ρ₀, …, -ρₙ₋₁, -ρ₀ ⋅ c₀, …, -ρₙ₋₁ ⋅ cₙ₋₁]
+		points := make([]bls24317.G1Affine, 2*n+1)
+		scalars := make([]fr.Element, 2*n+1)
+		points[0] = g1GenAff
+		for i := 0; i < n; i++ {
+			var rho, t fr.Element
+			if _, err := rho.SetRandom(); err != nil {
+				batchOK = false
+				break
+			}
+			c := challenge(&sigs[i].R, &pubs[i], msgs[i], config)
+
+			t.Mul(&rho, &sigs[i].S)
+			scalars[0].Add(&scalars[0], &t)
+
+			points[1+i] = sigs[i].R
+			scalars[1+i].Neg(&rho)
+
+			points[1+n+i] = pubs[i]
+			scalars[1+n+i].Mul(&rho, &c).Neg(&scalars[1+n+i])
+		}
+
+		if batchOK {
+			var res bls24317.G1Jac
+			if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{ScalarsMont: true}); err == nil && res.Z.IsZero() {
+				return true, nil
+			}
+		}
+	}
+
+	// second pass: find the invalid signatures
+	var invalid []int
+	for i := 0; i < n; i++ {
+		if !Verify(&pubs[i], msgs[i], &sigs[i], config...) {
+			invalid = append(invalid, i)
+		}
+	}
+	return len(invalid) == 0, invalid
+}
+
+// noKeyPrefix returns true if config selects the H(R, m) challenge
+func noKeyPrefix(config []Config) bool {
+	return len(config) == 1 && config[0].NoKeyPrefix
+}
+
+// challenge returns c = H(R, P, m), or H(R, m) if the key prefix is disabled in config
+func challenge(R, pub *bls24317.G1Affine, message []byte, config []Config) fr.Element {
+	rb := R.Bytes()
+	if noKeyPrefix(config) {
+		return fr.HashToScalar(challengeDST, rb[:], message)
+	}
+	pb := pub.Bytes()
+	return fr.HashToScalar(challengeDST, rb[:], pb[:], message)
+}
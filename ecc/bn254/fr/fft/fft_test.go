@@ -279,6 +279,174 @@ func BenchmarkFFT(b *testing.B) {
 
 }
 
+func TestLagrangeMonomial(t *testing.T) {
+	const size = 64
+	domain := NewDomain(size)
+
+	// random polynomial in monomial basis
+	coeffs := make([]fr.Element, size)
+	for i := 0; i < size; i++ {
+		coeffs[i].SetRandom()
+	}
+
+	// MonomialToLagrange must match the evaluations on the domain, in natural order
+	evals := make([]fr.Element, size)
+	copy(evals, coeffs)
+	domain.MonomialToLagrange(evals)
+	var x fr.Element
+	x.SetOne()
+	for i := 0; i < size; i++ {
+		expected := evaluatePolynomial(coeffs, x)
+		if !evals[i].Equal(&expected) {
+			t.Fatal("MonomialToLagrange doesn't match the evaluations on the domain")
+		}
+		x.Mul(&x, &domain.Generator)
+	}
+
+	// LagrangeToMonomial(MonomialToLagrange(p)) == p
+	domain.LagrangeToMonomial(evals)
+	for i := 0; i < size; i++ {
+		if !evals[i].Equal(&coeffs[i]) {
+			t.Fatal("LagrangeToMonomial(MonomialToLagrange(p)) != p")
+		}
+	}
+
+	// MonomialToLagrange(LagrangeToMonomial(v)) == v
+	copy(evals, coeffs)
+	domain.LagrangeToMonomial(evals)
+	domain.MonomialToLagrange(evals)
+	for i := 0; i < size; i++ {
+		if !evals[i].Equal(&coeffs[i]) {
+			t.Fatal("MonomialToLagrange(LagrangeToMonomial(v)) != v")
+		}
+	}
+}
+
+func TestForwardInverse(t *testing.T) {
+	for _, size := range []uint64{8, 64, 1024} {
+		domain := NewDomain(size)
+
+		a := make([]fr.Element, size)
+		backup := make([]fr.Element, size)
+		for i := range a {
+			a[i].SetRandom()
+		}
+		copy(backup, a)
+
+		// Forward outputs the evaluations in bit-reversed order
+		evals := make([]fr.Element, size)
+		copy(evals, a)
+		domain.MonomialToLagrange(evals)
+		BitReverse(evals)
+
+		domain.Forward(a)
+		for i := range a {
+			if !a[i].Equal(&evals[i]) {
+				t.Fatal("Forward should output the bit-reversed evaluations")
+			}
+		}
+
+		domain.Inverse(a)
+		for i := range a {
+			if !a[i].Equal(&backup[i]) {
+				t.Fatal("Inverse(Forward(a)) != a")
+			}
+		}
+	}
+}
+
+func TestDivideByVanishing(t *testing.T) {
+	const n = 16
+	domain := NewDomain(n)
+
+	for _, qSize := range []int{1, 5, n, 3*n + 2} {
+		// p = q⋅(Xⁿ-1)
+		q := make([]fr.Element, qSize)
+		p := make([]fr.Element, qSize+n)
+		for i := 0; i < qSize; i++ {
+			q[i].SetRandom()
+			p[i].Sub(&p[i], &q[i])
+			p[i+n].Add(&p[i+n], &q[i])
+		}
+
+		quotient, ok := domain.DivideByVanishing(p)
+		if !ok {
+			t.Fatal("p is divisible by Xⁿ-1")
+		}
+		if len(quotient) != qSize {
+			t.Fatal("wrong quotient size")
+		}
+		for i := 0; i < qSize; i++ {
+			if !quotient[i].Equal(&q[i]) {
+				t.Fatal("wrong quotient")
+			}
+		}
+
+		// p+X is not divisible
+		var one fr.Element
+		one.SetOne()
+		p[1].Add(&p[1], &one)
+		if quotient, ok = domain.DivideByVanishing(p); ok || quotient != nil {
+			t.Fatal("p+X is not divisible by Xⁿ-1")
+		}
+	}
+
+	// a non zero polynomial of degree < n isn't divisible, 0 is
+	p := make([]fr.Element, n)
+	if _, ok := domain.DivideByVanishing(p); !ok {
+		t.Fatal("0 is divisible by Xⁿ-1")
+	}
+	p[n-1].SetOne()
+	if _, ok := domain.DivideByVanishing(p); ok {
+		t.Fatal("Xⁿ⁻¹ is not divisible by Xⁿ-1")
+	}
+}
+
+// BenchmarkFFTSmall measures FFT round trips on small domains (e.g. as in lattice based hashes),
+// which must not allocate.
+func BenchmarkFFTSmall(b *testing.B) {
+	for _, size := range []int{64, 256} {
+		pol := make([]fr.Element, size)
+		for i := 0; i < size; i++ {
+			pol[i].SetRandom()
+		}
+		domain := NewDomain(uint64(size))
+		b.Run("fft+inverse "+strconv.Itoa(size), func(b *testing.B) {
+			b.ReportAllocs()
+			for j := 0; j < b.N; j++ {
+				domain.FFT(pol, DIF, true)
+				domain.FFTInverse(pol, DIT, true)
+			}
+		})
+	}
+}
+
+func TestFFTSmallNoAlloc(t *testing.T) {
+	const size = 64
+	pol := make([]fr.Element, size)
+	for i := 0; i < size; i++ {
+		pol[i].SetRandom()
+	}
+	backup := make([]fr.Element, size)
+	copy(backup, pol)
+	domain := NewDomain(size)
+
+	for _, coset := range []bool{false, true} {
+		allocs := testing.AllocsPerRun(10, func() {
+			domain.FFT(pol, DIF, coset)
+			domain.FFTInverse(pol, DIT, coset)
+		})
+		if allocs != 0 {
+			t.Fatalf("expected no allocation, got %v", allocs)
+		}
+	}
+	for i := 0; i < size; i++ {
+		if !pol[i].Equal(&backup[i]) {
+			t.Fatal("FFTInverse(FFT(p)) != p")
+		}
+	}
+}
+
 func BenchmarkFFTDITCosetReference(b *testing.B) {
 	const maxSize = 1 << 20
 
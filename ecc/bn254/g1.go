@@ -17,8 +17,11 @@
 package bn254
 
 import (
+	"crypto/subtle"
+	"errors"
 	"math/big"
 	"runtime"
+	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
@@ -51,6 +54,10 @@ func (p *G1Affine) Set(a *G1Affine) *G1Affine {
 }
 
 // ScalarMultiplication computes and returns p = a ⋅ s
+//
+// The result is converted back to affine coordinates, which costs a field inversion.
+// When chaining scalar multiplications, prefer ScalarMulChain or work with
+// G1Jac.ScalarMultiplicationAffine and convert once at the end.
 func (p *G1Affine) ScalarMultiplication(a *G1Affine, s *big.Int) *G1Affine {
 	var _p G1Jac
 	_p.FromAffine(a)
@@ -59,6 +66,43 @@ func (p *G1Affine) ScalarMultiplication(a *G1Affine, s *big.Int) *G1Affine {
 	return p
 }
 
+// ScalarMultiplicationCanonical computes and returns p = a ⋅ s like ScalarMultiplication,
+// but returns an error (and leaves p unchanged) if s is not in the canonical range [0, r)
+// instead of silently reducing it.
+func (p *G1Affine) ScalarMultiplicationCanonical(a *G1Affine, s *big.Int) (*G1Affine, error) {
+	if s.Sign() < 0 || s.Cmp(fr.Modulus()) >= 0 {
+		return p, errors.New("invalid scalar: not in [0, r)")
+	}
+	return p.ScalarMultiplication(a, s), nil
+}
+
+// ScalarDiv computes and returns p = a ⋅ s⁻¹, where s⁻¹ is the inverse of s modulo r
+//
+// It returns an error (and leaves p unchanged) if s is not invertible modulo r, i.e. if s ≡ 0 mod r.
+// Like ScalarMultiplication, it assumes a is in the prime-order subgroup.
+func (p *G1Affine) ScalarDiv(a *G1Affine, s *big.Int) (*G1Affine, error) {
+	var sInv big.Int
+	if sInv.ModInverse(s, fr.Modulus()) == nil {
+		return p, errors.New("invalid scalar: not invertible modulo r")
+	}
+	return p.ScalarMultiplication(a, &sInv), nil
+}
+
+// ScalarMulChain computes and returns p = a ⋅ s₀ ⋅ s₁ ⋯ sₙ₋₁
+//
+// Intermediate results are kept in Jacobian coordinates and a single conversion
+// to affine is performed at the end, whereas chaining calls to ScalarMultiplication
+// costs one field inversion per step.
+func (p *G1Affine) ScalarMulChain(a *G1Affine, scalars ...*big.Int) *G1Affine {
+	var _p G1Jac
+	_p.FromAffine(a)
+	for i := 0; i < len(scalars); i++ {
+		_p.mulGLV(&_p, scalars[i])
+	}
+	p.FromJacobian(&_p)
+	return p
+}
+
 // ScalarMultiplicationAffine computes and returns p = a ⋅ s
 // Takes an affine point and returns a Jacobian point (useful for KZG)
 func (p *G1Jac) ScalarMultiplicationAffine(a *G1Affine, s *big.Int) *G1Jac {
@@ -137,9 +181,17 @@ func (p *G1Affine) IsInfinity() bool {
 
 // IsOnCurve returns true if p in on the curve
 func (p *G1Affine) IsOnCurve() bool {
-	var point G1Jac
-	point.FromAffine(p)
-	return point.IsOnCurve() // call this function to handle infinity point
+	if p.IsInfinity() {
+		var point G1Jac
+		point.FromAffine(p)
+		return point.IsOnCurve() // call this function to handle infinity point
+	}
+	// y² == x³ + b, directly in affine coordinates
+	var left, right fp.Element
+	left.Square(&p.Y)
+	right.Square(&p.X).Mul(&right, &p.X)
+	right.Add(&right, &bCurveCoeff)
+	return left.Equal(&right)
 }
 
 // IsInSubGroup returns true if p is in the correct subgroup, false otherwise
@@ -149,6 +201,38 @@ func (p *G1Affine) IsInSubGroup() bool {
 	return _p.IsInSubGroup()
 }
 
+// IsSmallOrder returns true if the order of p is at most bound (the point at infinity has order 1).
+//
+// It adds p to itself up to bound times, so bound is meant to be small, e.g. the largest
+// small prime factor of the cofactor. Together with IsInSubGroup, it helps rejecting the
+// points used in small-subgroup confinement attacks.
+func (p *G1Affine) IsSmallOrder(bound int) bool {
+	if p.IsInfinity() {
+		return true
+	}
+	var q G1Jac
+	q.FromAffine(p)
+	for k := 2; k <= bound; k++ {
+		q.AddMixed(p)
+		if q.Z.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// MulByCofactor computes and returns p = h ⋅ a, where h is the cofactor of G1 (see CofactorG1)
+//
+// Unlike ClearCofactor, which may use a cheaper multiple of h, it computes the literal h ⋅ a,
+// without reducing h modulo r, so that the result is exact for points out of the r-torsion too.
+func (p *G1Affine) MulByCofactor(a *G1Affine) *G1Affine {
+	var _p G1Jac
+	_p.FromAffine(a)
+	_p.mulWindowed(&_p, &cofactorG1)
+	p.FromJacobian(&_p)
+	return p
+}
+
 // -------------------------------------------------------------------------------------------------
 // Jacobian
 
@@ -358,6 +442,18 @@ func (p *G1Jac) FromAffine(Q *G1Affine) *G1Jac {
 	return p
 }
 
+// XAffine returns the x-coordinate of p in affine coordinates, X/Z², without computing
+// the affine y-coordinate (see G1Affine.FromJacobian). It returns 0 if p is infinity.
+func (p *G1Jac) XAffine() fp.Element {
+	var x fp.Element
+	if p.Z.IsZero() {
+		return x
+	}
+	x.Square(&p.Z).Inverse(&x)
+	x.Mul(&x, &p.X)
+	return x
+}
+
 // IsOnCurve returns true if p in on the curve
 func (p *G1Jac) IsOnCurve() bool {
 	var left, right, tmp fp.Element
@@ -373,8 +469,8 @@ func (p *G1Jac) IsOnCurve() bool {
 }
 
 // IsInSubGroup returns true if p is on the r-torsion, false otherwise.
-// BN curves are of prime order i.e. E(𝔽p) is the full group
-// so we just check that the point is on the curve.
+// The group of points is of prime order r (the cofactor is 1, e.g. E(𝔽p) for BN curves),
+// i.e. it is the full r-torsion, so we just check that the point is on the curve.
 func (p *G1Jac) IsInSubGroup() bool {
 
 	return p.IsOnCurve()
@@ -411,6 +507,26 @@ func (p *G1Jac) mulWindowed(a *G1Jac, s *big.Int) *G1Jac {
 
 }
 
+// selectPoint sets p to table[index] and returns p.
+//
+// Unlike table[index], it reads every entry of the table and selects the right one with masks,
+// so that its memory access pattern does not depend on index (constant-time table lookup).
+// If index is not in [0, len(table)), p is set to the zero value.
+//
+// mulGLV and mulWindowed index their tables with secret scalar bits and are not constant time;
+// selectPoint is the lookup a constant-time scalar multiplication must use instead.
+func (p *G1Jac) selectPoint(table []G1Jac, index int) *G1Jac {
+	var res G1Jac
+	for i := range table {
+		c := subtle.ConstantTimeEq(int32(i), int32(index))
+		res.X.Select(c, &res.X, &table[i].X)
+		res.Y.Select(c, &res.Y, &table[i].Y)
+		res.Z.Select(c, &res.Z, &table[i].Z)
+	}
+	p.Set(&res)
+	return p
+}
+
 // ϕ assigns p to ϕ(a) where ϕ: (x,y) → (w x,y), and returns p
 // where w is a third root of unity in 𝔽p
 func (p *G1Jac) phi(a *G1Jac) *G1Jac {
@@ -476,7 +592,13 @@ func (p *G1Jac) mulGLV(a *G1Jac, s *big.Int) *G1Jac {
 	// loop starts from len(k1)/2 or len(k1)/2+1 due to the bounds
 	for i := hiWordIndex; i >= 0; i-- {
 		mask := uint64(3) << 62
-		for j := 0; j < 32; j++ {
+		j := 0
+		if i == hiWordIndex {
+			// skip the leading zero windows, small scalars need fewer iterations
+			j = (64 - (maxBit - 64*hiWordIndex)) / 2
+			mask >>= 2 * j
+		}
+		for ; j < 32; j++ {
 			res.Double(&res).Double(&res)
 			b1 := (k1[i] & mask) >> (62 - 2*j)
 			b2 := (k2[i] & mask) >> (62 - 2*j)
@@ -492,6 +614,45 @@ func (p *G1Jac) mulGLV(a *G1Jac, s *big.Int) *G1Jac {
 	return p
 }
 
+// -------------------------------------------------------------------------------------------------
+// Accumulator
+
+// G1Accumulator sums points as they arrive (e.g. to aggregate signatures).
+//
+// The running sum is kept in Jacobian coordinates and is only converted to affine
+// coordinates, which costs a field inversion, when calling Result.
+// The zero value is the point at infinity and is ready to use.
+type G1Accumulator struct {
+	sum G1Jac
+}
+
+// Add adds a to the running sum and returns acc
+func (acc *G1Accumulator) Add(a *G1Affine) *G1Accumulator {
+	acc.sum.AddMixed(a)
+	return acc
+}
+
+// AddScaled adds a ⋅ s to the running sum and returns acc
+func (acc *G1Accumulator) AddScaled(a *G1Affine, s *big.Int) *G1Accumulator {
+	var tmp G1Jac
+	tmp.ScalarMultiplicationAffine(a, s)
+	acc.sum.AddAssign(&tmp)
+	return acc
+}
+
+// Result returns the running sum in affine coordinates
+func (acc *G1Accumulator) Result() G1Affine {
+	var res G1Affine
+	res.FromJacobian(&acc.sum)
+	return res
+}
+
+// Reset sets the running sum to the point at infinity and returns acc
+func (acc *G1Accumulator) Reset() *G1Accumulator {
+	acc.sum = G1Jac{}
+	return acc
+}
+
 // -------------------------------------------------------------------------------------------------
 // Jacobian extended
 
@@ -795,7 +956,9 @@ func (p *g1JacExtended) doubleMixed(q *G1Affine) *g1JacExtended {
 
 // BatchJacobianToAffineG1 converts points in Jacobian coordinates to Affine coordinates
 // performing a single field inversion (Montgomery batch inversion trick).
-func BatchJacobianToAffineG1(points []G1Jac) []G1Affine {
+//
+// nbTasks optionally caps the number of go routines used (default: runtime.NumCPU()).
+func BatchJacobianToAffineG1(points []G1Jac, nbTasks ...int) []G1Affine {
 	result := make([]G1Affine, len(points))
 	zeroes := make([]bool, len(points))
 	accumulator := fp.One()
@@ -837,15 +1000,92 @@ func BatchJacobianToAffineG1(points []G1Jac) []G1Affine {
 			result[i].Y.Mul(&points[i].Y, &b).
 				Mul(&result[i].Y, &a)
 		}
-	})
+	}, nbTasks...)
+
+	return result
+}
+
+// BatchJacobianToAffineG1Chunked converts points in Jacobian coordinates to Affine coordinates,
+// like BatchJacobianToAffineG1, but processes the points by blocks of chunkSize points
+// (1 << 12 if chunkSize <= 0), each block with its own batch inversion.
+//
+// The blocks fit in the CPU caches and are processed in parallel, which is faster on huge slices
+// at the cost of one field inversion per block.
+//
+// nbTasks optionally caps the number of go routines used (default: runtime.NumCPU()).
+func BatchJacobianToAffineG1Chunked(points []G1Jac, chunkSize int, nbTasks ...int) []G1Affine {
+	if chunkSize <= 0 {
+		chunkSize = 1 << 12
+	}
+	result := make([]G1Affine, len(points))
+	nbChunks := (len(points) + chunkSize - 1) / chunkSize
+
+	parallel.Execute(nbChunks, func(start, end int) {
+		for chunk := start; chunk < end; chunk++ {
+			from := chunk * chunkSize
+			to := from + chunkSize
+			if to > len(points) {
+				to = len(points)
+			}
+			batchJacobianToAffineG1(points[from:to], result[from:to])
+		}
+	}, nbTasks...)
 
 	return result
 }
 
+// batchJacobianToAffineG1 sets result[i] to points[i] in affine coordinates,
+// performing a single field inversion.
+func batchJacobianToAffineG1(points []G1Jac, result []G1Affine) {
+	accumulator := fp.One()
+
+	// stores points[].Z^-1 in result[i].X
+	for i := 0; i < len(points); i++ {
+		if points[i].Z.IsZero() {
+			continue
+		}
+		result[i].X = accumulator
+		accumulator.Mul(&accumulator, &points[i].Z)
+	}
+
+	var accInverse fp.Element
+	accInverse.Inverse(&accumulator)
+
+	for i := len(points) - 1; i >= 0; i-- {
+		if points[i].Z.IsZero() {
+			// (X=0, Y=0) is infinity point in affine
+			result[i].X.SetZero()
+			result[i].Y.SetZero()
+			continue
+		}
+		var a, b fp.Element
+		a.Mul(&result[i].X, &accInverse)
+		accInverse.Mul(&accInverse, &points[i].Z)
+		b.Square(&a)
+		result[i].X.Mul(&points[i].X, &b)
+		result[i].Y.Mul(&points[i].Y, &b).
+			Mul(&result[i].Y, &a)
+	}
+}
+
 // BatchScalarMultiplicationG1 multiplies the same base by all scalars
 // and return resulting points in affine coordinates
 // uses a simple windowed-NAF like exponentiation algorithm
-func BatchScalarMultiplicationG1(base *G1Affine, scalars []fr.Element) []G1Affine {
+//
+// nbTasks optionally caps the number of go routines used (default: runtime.NumCPU()).
+func BatchScalarMultiplicationG1(base *G1Affine, scalars []fr.Element, nbTasks ...int) []G1Affine {
+
+	// no need to build the window tables for less than 2 scalars
+	switch len(scalars) {
+	case 0:
+		return []G1Affine{}
+	case 1:
+		var s big.Int
+		scalars[0].ToBigInt(&s) // scalars are not in Montgomery form
+		res := make([]G1Affine, 1)
+		res[0].ScalarMultiplication(base, &s)
+		return res
+	}
 
 	// approximate cost in group ops is
 	// cost = 2^{c-1} + n(scalar.nbBits+nbChunks)
@@ -903,7 +1143,7 @@ func BatchScalarMultiplicationG1(base *G1Affine, scalars []fr.Element) []G1Affin
 		selectors[chunk] = d
 	}
 	// convert our base exp table into affine to use AddMixed
-	baseTableAff := BatchJacobianToAffineG1(baseTable)
+	baseTableAff := BatchJacobianToAffineG1(baseTable, nbTasks...)
 	toReturn := make([]G1Jac, len(scalars))
 
 	// for each digit, take value in the base table, double it c time, voilà.
@@ -944,7 +1184,155 @@ func BatchScalarMultiplicationG1(base *G1Affine, scalars []fr.Element) []G1Affin
 			toReturn[i] = p
 
 		}
-	})
-	toReturnAff := BatchJacobianToAffineG1(toReturn)
+	}, nbTasks...)
+	toReturnAff := BatchJacobianToAffineG1(toReturn, nbTasks...)
 	return toReturnAff
 }
+
+// SumG1 returns Σᵢ points[i] in affine coordinates.
+//
+// The points are accumulated in Jacobian coordinates, hence a single field inversion is performed
+// at the end; large slices are split between go routines. Points at infinity are handled.
+//
+// nbTasks optionally caps the number of go routines used (default: runtime.NumCPU()).
+func SumG1(points []G1Affine, nbTasks ...int) G1Affine {
+	// below this size, the go routines overhead is not worth it
+	const minParallelSize = 1 << 10
+	if len(points) < minParallelSize {
+		nbTasks = []int{1}
+	}
+
+	var sum G1Jac
+	var lock sync.Mutex
+	sum.Set(&g1Infinity)
+
+	parallel.Execute(len(points), func(start, end int) {
+		var partial G1Jac
+		partial.Set(&g1Infinity)
+		for i := start; i < end; i++ {
+			partial.AddMixed(&points[i])
+		}
+		lock.Lock()
+		sum.AddAssign(&partial)
+		lock.Unlock()
+	}, nbTasks...)
+
+	var res G1Affine
+	res.FromJacobian(&sum)
+	return res
+}
+
+// FoldPointsG1 returns Σᵢ rⁱ⋅points[i] in affine coordinates, i.e. the random linear
+// combination of the points used to fold commitments or proofs with a challenge r.
+//
+// The powers of r are computed and the sum is performed with a single multi-exponentiation.
+// It returns the point at infinity if points is empty.
+func FoldPointsG1(points []G1Affine, r fr.Element) G1Affine {
+	var res G1Affine
+	if len(points) == 0 {
+		return res
+	}
+
+	// powers = [1, r, r², ..., rⁿ⁻¹]
+	powers := make([]fr.Element, len(points))
+	powers[0].SetOne()
+	for i := 1; i < len(powers); i++ {
+		powers[i].Mul(&powers[i-1], &r)
+	}
+
+	// the sizes match and the config is valid, MultiExp can't fail
+	_, _ = res.MultiExp(points, powers, ecc.MultiExpConfig{ScalarsMont: true})
+	return res
+}
+
+// BatchScalarMulG1 multiplies each base by its own scalar,
+// i.e. it returns [scalars[i] ⋅ bases[i]] (the results are not summed, see MultiExp for that)
+// in affine coordinates.
+//
+// It panics if len(bases) != len(scalars).
+//
+// nbTasks optionally caps the number of go routines used (default: runtime.NumCPU()).
+func BatchScalarMulG1(bases []G1Affine, scalars []fr.Element, nbTasks ...int) []G1Affine {
+	if len(bases) != len(scalars) {
+		panic("len(bases) != len(scalars)")
+	}
+	if len(scalars) == 0 {
+		return []G1Affine{}
+	}
+	toReturn := make([]G1Jac, len(scalars))
+
+	parallel.Execute(len(scalars), func(start, end int) {
+		var s big.Int
+		for i := start; i < end; i++ {
+			scalars[i].ToBigIntRegular(&s)
+			toReturn[i].ScalarMultiplicationAffine(&bases[i], &s)
+		}
+	}, nbTasks...)
+	// batch convert the results to affine (single field inversion)
+	return BatchJacobianToAffineG1(toReturn, nbTasks...)
+}
+
+// G1PrecomputedTwoBase holds a combined window table to compute a ⋅ G + b ⋅ H
+// for two fixed bases G and H (e.g. the generators of a Pedersen commitment).
+//
+// The table stores i ⋅ G + j ⋅ H for 0 ≤ i, j < 4 (omitting ∞), so that G, H and G+H
+// (and the other combinations of 2-bit windows) are added without any further doubling.
+type G1PrecomputedTwoBase struct {
+	table [15]G1Affine
+}
+
+// NewG1PrecomputedTwoBase precomputes the combined window table for the bases g and h.
+func NewG1PrecomputedTwoBase(g, h *G1Affine) *G1PrecomputedTwoBase {
+	var jac [16]G1Jac
+	jac[0].Set(&g1Infinity)
+	jac[1].FromAffine(g)
+	jac[4].FromAffine(h)
+	// jac[i + 4j] = i ⋅ G + j ⋅ H
+	for c := 2; c < 16; c++ {
+		if c == 4 {
+			continue
+		}
+		if c%4 == 0 {
+			jac[c].Set(&jac[c-4]).AddAssign(&jac[4])
+		} else {
+			jac[c].Set(&jac[c-1]).AddAssign(&jac[1])
+		}
+	}
+
+	t := new(G1PrecomputedTwoBase)
+	for c := 1; c < 16; c++ {
+		t.table[c-1].FromJacobian(&jac[c])
+	}
+	return t
+}
+
+// Mul returns a ⋅ G + b ⋅ H in affine coordinates.
+//
+// It uses the Straus-Shamir trick: a single pass over the 2-bit windows of a and b,
+// with two doublings and at most one addition from the table per window.
+// The scalars are reduced modulo r, so G and H are expected to be in the prime-order subgroup.
+func (t *G1PrecomputedTwoBase) Mul(a, b *big.Int) G1Affine {
+	var _a, _b big.Int
+	_a.Mod(a, fr.Modulus())
+	_b.Mod(b, fr.Modulus())
+
+	n := _a.BitLen()
+	if _b.BitLen() > n {
+		n = _b.BitLen()
+	}
+	n += n & 1
+
+	var res G1Jac
+	res.Set(&g1Infinity)
+	for i := n - 2; i >= 0; i -= 2 {
+		res.DoubleAssign().DoubleAssign()
+		c := (_a.Bit(i) | _a.Bit(i+1)<<1) | (_b.Bit(i)|_b.Bit(i+1)<<1)<<2
+		if c != 0 {
+			res.AddMixed(&t.table[c-1])
+		}
+	}
+
+	var p G1Affine
+	p.FromJacobian(&res)
+	return p
+}
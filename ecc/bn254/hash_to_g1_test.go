@@ -143,6 +143,52 @@ func TestHashToG1(t *testing.T) {
 	}
 }
 
+func TestHashToTwoG1(t *testing.T) {
+	t.Parallel()
+	dst := hashToG1Vector.dst
+	for _, c := range hashToG1Vector.cases {
+		h0, h1, err := HashToTwoG1([]byte(c.msg), dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h0.Equal(&h1) {
+			t.Fatal("HashToTwoG1 outputs should be distinct")
+		}
+		if !h0.IsInSubGroup() || !h1.IsInSubGroup() {
+			t.Fatal("HashToTwoG1 outputs should be in the subgroup")
+		}
+
+		// outputs must not collide with HashToG1 using the same dst
+		h, err := HashToG1([]byte(c.msg), dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.Equal(&h0) || h.Equal(&h1) {
+			t.Fatal("HashToTwoG1 outputs should differ from HashToG1")
+		}
+
+		// nor with HashToG1 using a dst derived from dst
+		for _, suffix := range []string{"_0", "_1", "0", "1"} {
+			h, err = HashToG1([]byte(c.msg), append(append([]byte{}, dst...), suffix...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if h.Equal(&h0) || h.Equal(&h1) {
+				t.Fatalf("HashToTwoG1 outputs should differ from HashToG1 with dst || %q", suffix)
+			}
+		}
+
+		// reproducible
+		_h0, _h1, err := HashToTwoG1([]byte(c.msg), dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !_h0.Equal(&h0) || !_h1.Equal(&h1) {
+			t.Fatal("HashToTwoG1 should be deterministic")
+		}
+	}
+}
+
 func BenchmarkEncodeToG1(b *testing.B) {
 	const size = 54
 	bytes := make([]byte, size)
@@ -21,9 +21,11 @@ import "github.com/consensys/gnark-crypto/ecc/bn254/fp"
 
 // MulByNonResidue multiplies a E2 by (9,1)
 func (z *E2) MulByNonResidue(x *E2) *E2 {
-	var a, b fp.Element
-	a.Double(&x.A0).Double(&a).Double(&a).Add(&a, &x.A0).Sub(&a, &x.A1)
-	b.Double(&x.A1).Double(&b).Double(&b).Add(&b, &x.A1).Add(&b, &x.A0)
+	a, b := x.A0, x.A1
+	fp.MulByConstant(&a, 9)
+	a.Sub(&a, &x.A1)
+	fp.MulByConstant(&b, 9)
+	b.Add(&b, &x.A0)
 	z.A0.Set(&a)
 	z.A1.Set(&b)
 	return z
@@ -16,12 +16,7 @@ package fp
 
 // MulByNonResidue multiplies a fp.Element by 33
 func (z *Element) MulByNonResidue(x *Element) *Element {
-	var t Element
-	t.Double(x).
-		Double(&t).
-		Double(&t).
-		Double(&t).
-		Double(&t)
-	z.Add(&t, x)
+	z.Set(x)
+	MulByConstant(z, 33)
 	return z
 }
@@ -19,6 +19,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc/bw6-756/fp"
 	"github.com/consensys/gnark-crypto/ecc/bw6-756/internal/fptower"
+	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
 // GT target group of the pairing
@@ -33,6 +34,12 @@ type lineEvaluation struct {
 // Pair calculates the reduced pairing for a set of points
 // ∏ᵢ e(Pᵢ, Qᵢ).
 //
+// The Miller loop is the optimal Tate one, which is the only variant implemented
+// for bw6-756; see PairOptimalTate.
+//
+// If Pᵢ or Qᵢ is the point at infinity, e(Pᵢ, Qᵢ) = 1; in particular Pair returns
+// the identity of GT if all pairs contain the point at infinity.
+//
 // This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
 func Pair(P []G1Affine, Q []G2Affine) (GT, error) {
 	f, err := MillerLoop(P, Q)
@@ -42,6 +49,16 @@ func Pair(P []G1Affine, Q []G2Affine) (GT, error) {
 	return FinalExponentiation(&f), nil
 }
 
+// PairOptimalTate calculates the reduced optimal Tate pairing for a set of points
+// ∏ᵢ e(Pᵢ, Qᵢ).
+//
+// It is the default pairing of bw6-756, that is, Pair is equivalent to PairOptimalTate.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairOptimalTate(P []G1Affine, Q []G2Affine) (GT, error) {
+	return Pair(P, Q)
+}
+
 // PairingCheck calculates the reduced pairing for a set of points and returns True if the result is One
 // ∏ᵢ e(Pᵢ, Qᵢ) =? 1
 //
@@ -56,6 +73,86 @@ func PairingCheck(P []G1Affine, Q []G2Affine) (bool, error) {
 	return f.Equal(&one), nil
 }
 
+// PairingEquality returns true if e(a, b) = e(c, d).
+//
+// It checks e(a, b) ⋅ e(-c, d) = 1 instead, which shares a single Miller loop and
+// a single final exponentiation between the two pairings.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairingEquality(a G1Affine, b G2Affine, c G1Affine, d G2Affine) bool {
+	ok, _ := PairingProductEquality([]G1Affine{a}, []G2Affine{b}, []G1Affine{c}, []G2Affine{d})
+	return ok
+}
+
+// PairingProductEquality returns true if ∏ᵢ e(Pᵢ, Qᵢ) = ∏ⱼ e(Rⱼ, Sⱼ).
+//
+// It checks ∏ᵢ e(Pᵢ, Qᵢ) ⋅ ∏ⱼ e(-Rⱼ, Sⱼ) = 1 instead, with a single (multi) Miller loop
+// and a single final exponentiation. See PairingCheck.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairingProductEquality(P []G1Affine, Q []G2Affine, R []G1Affine, S []G2Affine) (bool, error) {
+	if len(P) != len(Q) || len(R) != len(S) {
+		return false, errors.New("invalid inputs sizes")
+	}
+
+	g1 := make([]G1Affine, len(P)+len(R))
+	g2 := make([]G2Affine, len(Q)+len(S))
+	copy(g1, P)
+	copy(g2, Q)
+	copy(g2[len(Q):], S)
+	for j := range R {
+		g1[len(P)+j].Neg(&R[j])
+	}
+
+	return PairingCheck(g1, g2)
+}
+
+// PairBatch calculates the reduced pairings e(Pᵢ, Qᵢ) of independent pairs, in parallel.
+//
+// Unlike Pair, which returns the product ∏ᵢ e(Pᵢ, Qᵢ), it returns one pairing per pair:
+// the i-th result is e(Pᵢ, Qᵢ).
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func PairBatch(P []G1Affine, Q []G2Affine) ([]GT, error) {
+	if len(P) != len(Q) {
+		return nil, errors.New("invalid inputs sizes")
+	}
+	res := make([]GT, len(P))
+	parallel.Execute(len(P), func(start, end int) {
+		for i := start; i < end; i++ {
+			// a single pair has consistent sizes, so Pair can't fail
+			res[i], _ = Pair(P[i:i+1], Q[i:i+1])
+		}
+	})
+	return res, nil
+}
+
+// AccumulateMillerLoop multiplies acc by the Miller loop of the pairs (P[i], Q[i]), without
+// the final exponentiation, and returns acc.
+//
+// It lets accumulation schemes defer the final exponentiation: acc must be initialized
+// to 1 (acc.SetOne()), and after accumulating any number of sets of pairs,
+// FinalizeAccumulator(acc) is equivalent to a single PairingCheck on all the pairs.
+//
+// This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
+func AccumulateMillerLoop(acc *GT, P []G1Affine, Q []G2Affine) (*GT, error) {
+	f, err := MillerLoop(P, Q)
+	if err != nil {
+		return acc, err
+	}
+	return acc.Mul(acc, &f), nil
+}
+
+// FinalizeAccumulator performs the final exponentiation of a product of Miller loops accumulated
+// with AccumulateMillerLoop, and returns true if the result is 1, i.e. if the product of all
+// the accumulated pairings is 1. acc is left unchanged.
+func FinalizeAccumulator(acc *GT) bool {
+	f := FinalExponentiation(acc)
+	var one GT
+	one.SetOne()
+	return f.Equal(&one)
+}
+
 // FinalExponentiation computes the exponentiation (∏ᵢ zᵢ)ᵈ
 // where d = (p^6-1)/r = (p^6-1)/Φ_6(p) ⋅ Φ_6(p)/r = (p^3-1)(p+1)(p^2 - p +1)/r
 // we use instead d=s ⋅ (p^3-1)(p+1)(p^2 - p +1)/r
@@ -156,6 +253,8 @@ func FinalExponentiation(z *GT, _z ...*GT) GT {
 // computes the multi-Miller loop ∏ᵢ MillerLoop(Pᵢ, Qᵢ)
 // Alg.2 in https://eprint.iacr.org/2021/1359.pdf
 // Eq. (6) in https://hackmd.io/@gnark/BW6-761-changes
+//
+// Pairs where Pᵢ or Qᵢ is the point at infinity are skipped, as e(O, Qᵢ) = e(Pᵢ, O) = 1.
 func MillerLoop(P []G1Affine, Q []G2Affine) (GT, error) {
 	// check input size match
 	n := len(P)
@@ -299,6 +398,329 @@ func MillerLoop(P []G1Affine, Q []G2Affine) (GT, error) {
 	return result, nil
 }
 
+// MillerLoopLines computes the Miller loop of a single pair (P, Q), as MillerLoop, and also
+// returns the lines evaluated at Q.
+//
+// lines[i] is the product of the lines evaluated at Q during the i-th iteration of the loop
+// (the doubling line, the addition line if any, and the precomputed line through P and its
+// image by the endomorphism when the addition involves both), so that the Miller loop
+// output f is obtained by f ← 1, then f ← f²⋅lines[i] for each i.
+//
+// If P or Q is the point at infinity, lines is empty and f = 1.
+func MillerLoopLines(P G1Affine, Q G2Affine) ([]GT, GT) {
+	var f GT
+	f.SetOne()
+	if P.IsInfinity() || Q.IsInfinity() {
+		return nil, f
+	}
+
+	// precomputations, see MillerLoop
+	p0 := P
+	var p1 G1Affine
+	p1.Y.Neg(&p0.Y)
+	p1.X.Mul(&p0.X, &thirdRootOneG2)
+	var pProj1, pProj01, pProj10 g1Proj
+	pProj1.FromAffine(&p1)
+
+	// l_{p0,p1}(q)
+	var l01, l10 lineEvaluation
+	pProj01.Set(&pProj1)
+	pProj01.AddMixedStep(&l01, &p0)
+	l01.r1.Mul(&l01.r1, &Q.X)
+	l01.r0.Mul(&l01.r0, &Q.Y)
+
+	// p0-p1
+	pProj10.Neg(&pProj1)
+	pProj10.AddMixedStep(&l10, &p0)
+	p := BatchProjectiveToAffineG1([]g1Proj{pProj01, pProj10})
+	p01, p10 := p[0], p[1]
+
+	var l lineEvaluation
+	// evalLine multiplies z by the line l evaluated at Q
+	evalLine := func(z *GT) {
+		l.r1.Mul(&l.r1, &Q.X)
+		l.r0.Mul(&l.r0, &Q.Y)
+		z.MulBy034(&l.r0, &l.r1, &l.r2)
+	}
+
+	lines := make([]GT, len(loopCounter0)-1)
+
+	// i = len(loopCounter) - 2
+	lines[0].SetOne()
+	pProj1.DoubleStep(&l)
+	evalLine(&lines[0])
+	f.Set(&lines[0])
+
+	var addend G1Affine
+	for i := len(loopCounter0) - 3; i >= 0; i-- {
+		line := &lines[len(loopCounter0)-2-i]
+		line.SetOne()
+		pProj1.DoubleStep(&l)
+		evalLine(line)
+
+		j := loopCounter1[i]*3 + loopCounter0[i]
+		switch j {
+		case -4:
+			addend.Neg(&p01)
+		case -3:
+			addend.Neg(&p1)
+		case -2:
+			addend.Set(&p10)
+		case -1:
+			addend.Neg(&p0)
+		case 1:
+			addend.Set(&p0)
+		case 2:
+			addend.Neg(&p10)
+		case 3:
+			addend.Set(&p1)
+		case 4:
+			addend.Set(&p01)
+		}
+		if j != 0 {
+			pProj1.AddMixedStep(&l, &addend)
+			evalLine(line)
+		}
+		switch j {
+		case -4, 4:
+			line.MulBy034(&l01.r0, &l01.r1, &l01.r2)
+		case -2, 2:
+			line.MulBy034(&l01.r0, &l01.r1, &l01.r2)
+		}
+
+		// (∏ᵢfᵢ)² × ℓ
+		f.Square(&f).Mul(&f, line)
+	}
+
+	return lines, f
+}
+
+// NbMillerLoopIterations is the number of iterations of the Miller loop,
+// i.e. the largest checkpoint accepted by MillerLoopSegments.
+const NbMillerLoopIterations = len(loopCounter0) - 1
+
+// MillerLoopState is a checkpoint of the Miller loop of pairs (P, Q), as returned by MillerLoopSegments.
+//
+// It holds the Miller function accumulated so far and the current multiples of the P,
+// so that the loop can be resumed from it, e.g. to bound the depth of a recursive
+// pairing computation by splitting the Miller loop in segments.
+type MillerLoopState struct {
+	// Iteration is the number of iterations of the loop already computed
+	Iteration int
+
+	// F is the Miller function accumulated so far
+	F GT
+
+	q []G2Affine
+
+	// precomputations, see MillerLoop
+	p0, p1, p01, p10 []G1Affine
+	l01              []lineEvaluation
+	pProj1           []g1Proj
+}
+
+// NewMillerLoopState returns the state of the Miller loop of the pairs (P[i], Q[i])
+// before its first iteration.
+func NewMillerLoopState(P []G1Affine, Q []G2Affine) (MillerLoopState, error) {
+	// check input size match
+	n := len(P)
+	if n == 0 || n != len(Q) {
+		return MillerLoopState{}, errors.New("invalid inputs sizes")
+	}
+
+	// filter infinity points
+	var s MillerLoopState
+	p := make([]G1Affine, 0, n)
+	s.q = make([]G2Affine, 0, n)
+	for k := 0; k < n; k++ {
+		if P[k].IsInfinity() || Q[k].IsInfinity() {
+			continue
+		}
+		p = append(p, P[k])
+		s.q = append(s.q, Q[k])
+	}
+	n = len(s.q)
+
+	// precomputations
+	s.p0 = make([]G1Affine, n)
+	s.p1 = make([]G1Affine, n)
+	s.pProj1 = make([]g1Proj, n)
+	pProj01 := make([]g1Proj, n)
+	pProj10 := make([]g1Proj, n)
+	s.l01 = make([]lineEvaluation, n)
+	var l10 lineEvaluation
+	for k := 0; k < n; k++ {
+		s.p0[k].Set(&p[k])
+		s.p1[k].Y.Neg(&p[k].Y)
+		s.p1[k].X.Mul(&p[k].X, &thirdRootOneG2)
+		s.pProj1[k].FromAffine(&s.p1[k])
+
+		// l_{p0,p1}(q)
+		pProj01[k].Set(&s.pProj1[k])
+		pProj01[k].AddMixedStep(&s.l01[k], &s.p0[k])
+		s.l01[k].r1.Mul(&s.l01[k].r1, &s.q[k].X)
+		s.l01[k].r0.Mul(&s.l01[k].r0, &s.q[k].Y)
+
+		// p0-p1
+		pProj10[k].Neg(&s.pProj1[k])
+		pProj10[k].AddMixedStep(&l10, &s.p0[k])
+	}
+	s.p01 = BatchProjectiveToAffineG1(pProj01)
+	s.p10 = BatchProjectiveToAffineG1(pProj10)
+	s.F.SetOne()
+
+	return s, nil
+}
+
+// MillerLoopSegments computes the Miller loop of the pairs (P[i], Q[i]), as MillerLoop,
+// and returns its state after each of the given numbers of iterations.
+//
+// checkpoints must be non-decreasing and in [0, NbMillerLoopIterations]. The returned states
+// don't share memory, and completing any of them gives the output of MillerLoop.
+func MillerLoopSegments(P []G1Affine, Q []G2Affine, checkpoints []int) ([]MillerLoopState, error) {
+	s, err := NewMillerLoopState(P, Q)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]MillerLoopState, len(checkpoints))
+	for i, c := range checkpoints {
+		if c < s.Iteration || c > NbMillerLoopIterations {
+			return nil, errors.New("checkpoints should be non-decreasing and at most NbMillerLoopIterations")
+		}
+		s.run(c - s.Iteration)
+		states[i] = s.clone()
+	}
+
+	return states, nil
+}
+
+// Resume returns the state of the Miller loop after up to n more iterations.
+// s is left unchanged.
+func (s *MillerLoopState) Resume(n int) MillerLoopState {
+	res := s.clone()
+	res.run(n)
+	return res
+}
+
+// Complete computes the remaining iterations of the Miller loop and returns its output,
+// as MillerLoop. s is left unchanged.
+func (s *MillerLoopState) Complete() GT {
+	res := s.Resume(NbMillerLoopIterations - s.Iteration)
+
+	return res.F
+}
+
+// run computes up to n more iterations of the Miller loop
+func (s *MillerLoopState) run(n int) {
+	for ; n > 0 && s.Iteration < NbMillerLoopIterations; n-- {
+		s.step()
+	}
+}
+
+// step computes the next iteration of the Miller loop
+func (s *MillerLoopState) step() {
+	i := len(loopCounter0) - 2 - s.Iteration
+	s.Iteration++
+
+	var l lineEvaluation
+
+	// (∏ᵢfᵢ)²
+	s.F.Square(&s.F)
+
+	for k := 0; k < len(s.q); k++ {
+		s.pProj1[k].DoubleStep(&l)
+		// line evaluation
+		l.r1.Mul(&l.r1, &s.q[k].X)
+		l.r0.Mul(&l.r0, &s.q[k].Y)
+		s.F.MulBy034(&l.r0, &l.r1, &l.r2)
+	}
+
+	// the first iteration is a doubling only
+	if i == len(loopCounter0)-2 {
+		return
+	}
+	j := loopCounter1[i]*3 + loopCounter0[i]
+	if j == 0 {
+		return
+	}
+
+	var addend G1Affine
+	for k := 0; k < len(s.q); k++ {
+		switch j {
+		case -4:
+			addend.Neg(&s.p01[k])
+		case -3:
+			addend.Neg(&s.p1[k])
+		case -2:
+			addend.Set(&s.p10[k])
+		case -1:
+			addend.Neg(&s.p0[k])
+		case 1:
+			addend.Set(&s.p0[k])
+		case 2:
+			addend.Neg(&s.p10[k])
+		case 3:
+			addend.Set(&s.p1[k])
+		case 4:
+			addend.Set(&s.p01[k])
+		}
+		s.pProj1[k].AddMixedStep(&l, &addend)
+		// line evaluation
+		l.r1.Mul(&l.r1, &s.q[k].X)
+		l.r0.Mul(&l.r0, &s.q[k].Y)
+		s.F.MulBy034(&l.r0, &l.r1, &l.r2)
+
+		switch j {
+		case -4, 4:
+			s.F.MulBy034(&s.l01[k].r0, &s.l01[k].r1, &s.l01[k].r2)
+		case -2, 2:
+			s.F.MulBy034(&s.l01[k].r0, &s.l01[k].r1, &s.l01[k].r2)
+		}
+	}
+}
+
+// clone returns a deep copy of s
+func (s *MillerLoopState) clone() MillerLoopState {
+	res := *s
+	res.pProj1 = make([]g1Proj, len(s.pProj1))
+	copy(res.pProj1, s.pProj1)
+	return res
+}
+
+// PrecomputedLines holds a point Q ∈ G2 for MillerLoopFixedQ.
+//
+// Unlike on the curves with an ate Miller loop, the lines can't be precomputed from Q
+// alone on bw6-756: the optimal Tate Miller loop iterates on the multiples of the G1
+// argument P and only evaluates the lines at Q. PrecomputeLines and MillerLoopFixedQ are
+// provided so that the pairing API is the same on every curve, but they save no work
+// over MillerLoop.
+type PrecomputedLines struct {
+	q G2Affine
+}
+
+// PrecomputeLines returns the PrecomputedLines of Q, see PrecomputedLines.
+func PrecomputeLines(Q G2Affine) PrecomputedLines {
+	return PrecomputedLines{q: Q}
+}
+
+// MillerLoopFixedQ computes the Miller loop of the pairs (P[i], Q[i]), as MillerLoop,
+// where lines[i] = PrecomputeLines(Q[i]).
+func MillerLoopFixedQ(P []G1Affine, lines []PrecomputedLines) (GT, error) {
+	// check input size match
+	n := len(P)
+	if n == 0 || n != len(lines) {
+		return GT{}, errors.New("invalid inputs sizes")
+	}
+
+	Q := make([]G2Affine, n)
+	for k := 0; k < n; k++ {
+		Q[k] = lines[k].q
+	}
+
+	return MillerLoop(P, Q)
+}
+
 // DoubleStep doubles a point in Homogenous projective coordinates, and evaluates the line in Miller loop
 // https://eprint.iacr.org/2013/722.pdf (Section 4.3)
 func (p *g1Proj) DoubleStep(evaluations *lineEvaluation) {
@@ -99,6 +99,34 @@ func (id ID) config() *config.Curve {
 	}
 }
 
+// GuessCurveFromSize returns the curve whose G1 or G2 affine points serialize to n bytes,
+// and whether that encoding is the compressed one.
+// ok is false if no implemented curve matches, or if several (curve, compression) pairs
+// share that size (e.g. 48 bytes is a compressed G1 point for all the BLS12 curves).
+func GuessCurveFromSize(n int) (id ID, compressed bool, ok bool) {
+	for _, c := range Implemented() {
+		cfg := c.config()
+		for _, g := range []config.Point{cfg.G1, cfg.G2} {
+			sizeCompressed := int(g.CoordExtDegree) * cfg.FpInfo.Bytes
+			for _, isCompressed := range []bool{true, false} {
+				size := sizeCompressed
+				if !isCompressed {
+					size *= 2
+				}
+				if size != n || (ok && id == c && compressed == isCompressed) {
+					continue
+				}
+				if ok {
+					// ambiguous size
+					return UNKNOWN, false, false
+				}
+				id, compressed, ok = c, isCompressed, true
+			}
+		}
+	}
+	return
+}
+
 func modulus(c *config.Curve, scalarField bool) *big.Int {
 	if scalarField {
 		return new(big.Int).Set(c.FrInfo.Modulus())
@@ -0,0 +1,78 @@
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bw6-633"
+	"github.com/consensys/gnark-crypto/ecc/bw6-756"
+	"github.com/consensys/gnark-crypto/ecc/bw6-761"
+)
+
+func TestGuessCurveFromSize(t *testing.T) {
+	t.Parallel()
+
+	type encoding struct {
+		id         ecc.ID
+		compressed bool
+		size       int
+	}
+	var encodings []encoding
+	add := func(id ecc.ID, g1Compressed, g1Uncompressed, g2Compressed, g2Uncompressed int) {
+		encodings = append(encodings,
+			encoding{id, true, g1Compressed},
+			encoding{id, false, g1Uncompressed},
+			encoding{id, true, g2Compressed},
+			encoding{id, false, g2Uncompressed},
+		)
+	}
+	add(ecc.BN254, bn254.SizeOfG1AffineCompressed, bn254.SizeOfG1AffineUncompressed, bn254.SizeOfG2AffineCompressed, bn254.SizeOfG2AffineUncompressed)
+	add(ecc.BLS12_377, bls12377.SizeOfG1AffineCompressed, bls12377.SizeOfG1AffineUncompressed, bls12377.SizeOfG2AffineCompressed, bls12377.SizeOfG2AffineUncompressed)
+	add(ecc.BLS12_378, bls12378.SizeOfG1AffineCompressed, bls12378.SizeOfG1AffineUncompressed, bls12378.SizeOfG2AffineCompressed, bls12378.SizeOfG2AffineUncompressed)
+	add(ecc.BLS12_381, bls12381.SizeOfG1AffineCompressed, bls12381.SizeOfG1AffineUncompressed, bls12381.SizeOfG2AffineCompressed, bls12381.SizeOfG2AffineUncompressed)
+	add(ecc.BLS24_315, bls24315.SizeOfG1AffineCompressed, bls24315.SizeOfG1AffineUncompressed, bls24315.SizeOfG2AffineCompressed, bls24315.SizeOfG2AffineUncompressed)
+	add(ecc.BLS24_317, bls24317.SizeOfG1AffineCompressed, bls24317.SizeOfG1AffineUncompressed, bls24317.SizeOfG2AffineCompressed, bls24317.SizeOfG2AffineUncompressed)
+	add(ecc.BW6_761, bw6761.SizeOfG1AffineCompressed, bw6761.SizeOfG1AffineUncompressed, bw6761.SizeOfG2AffineCompressed, bw6761.SizeOfG2AffineUncompressed)
+	add(ecc.BW6_633, bw6633.SizeOfG1AffineCompressed, bw6633.SizeOfG1AffineUncompressed, bw6633.SizeOfG2AffineCompressed, bw6633.SizeOfG2AffineUncompressed)
+	add(ecc.BW6_756, bw6756.SizeOfG1AffineCompressed, bw6756.SizeOfG1AffineUncompressed, bw6756.SizeOfG2AffineCompressed, bw6756.SizeOfG2AffineUncompressed)
+
+	for _, e := range encodings {
+		// the size is ambiguous if another curve, or the other encoding, has the same size
+		ambiguous := false
+		for _, o := range encodings {
+			if o.size == e.size && (o.id != e.id || o.compressed != e.compressed) {
+				ambiguous = true
+				break
+			}
+		}
+
+		id, compressed, ok := ecc.GuessCurveFromSize(e.size)
+		if ambiguous {
+			if ok {
+				t.Errorf("%s (compressed: %v): size %d is ambiguous, got %s (compressed: %v)", e.id, e.compressed, e.size, id, compressed)
+			}
+			continue
+		}
+		if !ok || id != e.id || compressed != e.compressed {
+			t.Errorf("%s (compressed: %v): size %d, got %s (compressed: %v, ok: %v)", e.id, e.compressed, e.size, id, compressed, ok)
+		}
+	}
+
+	// 48 bytes is a compressed G1 point on all the BLS12 curves
+	if _, _, ok := ecc.GuessCurveFromSize(48); ok {
+		t.Error("48 bytes should be ambiguous")
+	}
+
+	// no curve encodes its points on 1 or 0 bytes
+	for _, n := range []int{0, 1} {
+		if _, _, ok := ecc.GuessCurveFromSize(n); ok {
+			t.Errorf("%d bytes should not match any curve", n)
+		}
+	}
+}
@@ -157,6 +157,40 @@ func SplitScalar(s *big.Int, l *Lattice) [2]big.Int {
 	return v
 }
 
+// SplitScalarInto is an allocation-light variant of SplitScalar: it sets res to u,v
+// such that u+vlambda=s[r].
+// buf is used as scratch space; when called repeatedly (e.g. in a batch splitting loop)
+// with the same res and buf, their memory is reused instead of allocating new big.Int.
+// s must not alias res or buf.
+func SplitScalarInto(res *[2]big.Int, s *big.Int, l *Lattice, buf *[2]big.Int) {
+	k1, k2 := &buf[0], &buf[1]
+	k1.Mul(s, &l.b1)
+	k2.Mul(s, &l.b2).Neg(k2)
+	n := 2 * uint(((l.Det.BitLen()+32)>>6)<<6)
+	k1.Rsh(k1, n)
+	k2.Rsh(k2, n)
+
+	// res = (s, 0) - (k1.V1 + k2.V2)
+	res[0].Mul(k1, &l.V1[0])
+	res[1].Mul(k1, &l.V1[1])
+	k1.Mul(k2, &l.V2[0])
+	res[0].Add(&res[0], k1).Sub(s, &res[0])
+	k1.Mul(k2, &l.V2[1])
+	res[1].Add(&res[1], k1).Neg(&res[1])
+}
+
+// Set sets l to a deep copy of a and returns l
+func (l *Lattice) Set(a *Lattice) *Lattice {
+	l.V1[0].Set(&a.V1[0])
+	l.V1[1].Set(&a.V1[1])
+	l.V2[0].Set(&a.V2[0])
+	l.V2[1].Set(&a.V2[1])
+	l.Det.Set(&a.Det)
+	l.b1.Set(&a.b1)
+	l.b2.Set(&a.b2)
+	return l
+}
+
 // sets res to the closest integer from n/d
 func rounding(n, d, res *big.Int) {
 	var dshift, r, one big.Int
@@ -45,6 +45,38 @@ func TestSplitting(t *testing.T) {
 
 }
 
+func TestSplittingInto(t *testing.T) {
+	t.Parallel()
+
+	var lambda, r, s, _s big.Int
+	var l, lCopy Lattice
+
+	r.SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+	lambda.SetString("4407920970296243842393367215006156084916469457145843978461", 10)
+
+	PrecomputeLattice(&r, &lambda, &l)
+	lCopy.Set(&l)
+
+	var res, buf [2]big.Int
+	for i := 0; i < 100; i++ {
+		s.SetUint64(uint64(i)*0x9e3779b97f4a7c15+1).Mul(&s, &lambda).Mod(&s, &r)
+
+		SplitScalarInto(&res, &s, &lCopy, &buf)
+
+		expected := SplitScalar(&s, &l)
+		if res[0].Cmp(&expected[0]) != 0 || res[1].Cmp(&expected[1]) != 0 {
+			t.Fatal("SplitScalarInto and SplitScalar mismatch")
+		}
+
+		_s.Mul(&res[1], &lambda).Add(&_s, &res[0]).Sub(&_s, &s)
+		_s.Mod(&_s, &r)
+		if _s.Sign() != 0 {
+			t.Fatal("Error split scalar")
+		}
+	}
+
+}
+
 func BenchmarkSplitting256(b *testing.B) {
 
 	var lambda, r, s big.Int
@@ -62,13 +94,31 @@ func BenchmarkSplitting256(b *testing.B) {
 
 }
 
+func BenchmarkSplittingInto256(b *testing.B) {
+
+	var lambda, r, s big.Int
+	var l Lattice
+
+	r.SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+	lambda.SetString("4407920970296243842393367215006156084916469457145843978461", 10)
+	PrecomputeLattice(&r, &lambda, &l)
+	s.SetString("183927522224640574525727508854836440041603434369820418657580", 10)
+
+	var res, buf [2]big.Int
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SplitScalarInto(&res, &s, &l, &buf)
+	}
+
+}
+
 type expandMsgXmdTestCase struct {
 	msg             string
 	lenInBytes      int
 	uniformBytesHex string
 }
 
-//Test vectors from https://datatracker.ietf.org/doc/draft-irtf-cfrg-hash-to-curve/14/ Page 148 Section K.1.
+// Test vectors from https://datatracker.ietf.org/doc/draft-irtf-cfrg-hash-to-curve/14/ Page 148 Section K.1.
 func TestExpandMsgXmd(t *testing.T) {
 	//name := "expand_message_xmd"
 	dst := "QUUX-V01-CS02-with-expander-SHA256-128"
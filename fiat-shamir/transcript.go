@@ -24,6 +24,8 @@ var (
 	errChallengeNotFound            = errors.New("challenge not recorded in the Transcript")
 	errChallengeAlreadyComputed     = errors.New("challenge already computed, cannot be binded to other values")
 	errPreviousChallengeNotComputed = errors.New("the previous challenge is needed and has not been computed")
+	errInvalidFieldElements         = errors.New("binded value is not a sequence of field elements")
+	errTranscriptNotComplete        = errors.New("all the challenges of the absorbed transcript must be computed")
 )
 
 // Transcript handles the creation of challenges for Fiat Shamir.
@@ -33,6 +35,13 @@ type Transcript struct {
 
 	challenges map[string]challenge
 	previous   *challenge
+
+	// fieldNative is set if h is a field-native hash, see NewFieldTranscript.
+	fieldNative bool
+
+	// streaming is set if the values binded to the next challenge are written to h
+	// directly instead of being buffered, see NewStreamingTranscript.
+	streaming bool
 }
 
 type challenge struct {
@@ -40,6 +49,7 @@ type challenge struct {
 	bindings   []byte // bindings stores the variables a challenge is binded to.
 	value      []byte // value stores the computed challenge
 	isComputed bool
+	isStreamed bool // h holds the prefix and the bindings of the challenge (streaming mode)
 }
 
 // NewTranscript returns a new transcript.
@@ -59,6 +69,36 @@ func NewTranscript(h hash.Hash, challengesID ...string) Transcript {
 	return t
 }
 
+// NewFieldTranscript returns a new transcript whose challenges are computed with a
+// field-native hash h (e.g. MiMC or a Poseidon sponge), so that they can be recomputed
+// efficiently in a SNARK circuit.
+//
+// h absorbs field elements written as their big endian encoding on h.BlockSize() bytes,
+// and h.Sum squeezes a field element encoded the same way: the challenges are field
+// elements and need no further reduction. The values binded to a challenge must
+// be a sequence of such encoded field elements; the challenge names are absorbed as
+// field elements as well, split in big endian chunks of h.BlockSize()-1 bytes.
+func NewFieldTranscript(h hash.Hash, challengesID ...string) Transcript {
+	t := NewTranscript(h, challengesID...)
+	t.fieldNative = true
+	return t
+}
+
+// NewStreamingTranscript returns a new transcript which writes the values binded to a
+// challenge to h as they come, instead of buffering them until the challenge is computed.
+// The challenges are the same as the ones of NewTranscript, but binding large values
+// (e.g. full polynomials) takes O(1) memory.
+//
+// Only the next challenge to compute is streamed: values binded to a later challenge are
+// buffered until the challenge preceding it is computed. h is held by the transcript in
+// the meantime, so it must not be used elsewhere (e.g. to build a Merkle tree) between the
+// first Bind of a challenge and its ComputeChallenge.
+func NewStreamingTranscript(h hash.Hash, challengesID ...string) Transcript {
+	t := NewTranscript(h, challengesID...)
+	t.streaming = true
+	return t
+}
+
 // Bind binds the challenge to value. A challenge can be binded to an
 // arbitrary number of values, but the order in which the binded values
 // are added is important. Once a challenge is computed, it cannot be
@@ -73,13 +113,59 @@ func (t *Transcript) Bind(challengeID string, bValue []byte) error {
 	if challenge.isComputed {
 		return errChallengeAlreadyComputed
 	}
-	challenge.bindings = append(challenge.bindings, bValue...)
+	if t.fieldNative && len(bValue)%t.h.BlockSize() != 0 {
+		return errInvalidFieldElements
+	}
+
+	if t.streaming && t.isNext(&challenge) {
+		if !challenge.isStreamed {
+			// flush the values binded before the challenge became the next one
+			if err := t.writePrefix(challengeID, &challenge); err != nil {
+				return err
+			}
+			if _, err := t.h.Write(challenge.bindings); err != nil {
+				return err
+			}
+			challenge.bindings = nil
+			challenge.isStreamed = true
+		}
+		if _, err := t.h.Write(bValue); err != nil {
+			return err
+		}
+	} else {
+		challenge.bindings = append(challenge.bindings, bValue...)
+	}
 	t.challenges[challengeID] = challenge
 
 	return nil
 
 }
 
+// Absorb binds the challenge to the challenges of other, a transcript of a sub-protocol.
+//
+// All the challenges of other must be computed; their values are binded in the order
+// in which other declares them. Absorbing is thus equivalent to binding these values by
+// hand: absorbing a then b is the same as binding the challenges of a followed by
+// those of b, and differs from absorbing b then a.
+func (t *Transcript) Absorb(challengeID string, other *Transcript) error {
+
+	values := make([][]byte, len(other.challenges))
+	for _, c := range other.challenges {
+		if !c.isComputed {
+			return errTranscriptNotComplete
+		}
+		values[c.position] = c.value
+	}
+
+	for i := range values {
+		if err := t.Bind(challengeID, values[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ComputeChallenge computes the challenge corresponding to the given name.
 // The challenge is:
 // * H(name || previous_challenge || binded_values...) if the challenge is not the first one
@@ -96,29 +182,21 @@ func (t *Transcript) ComputeChallenge(challengeID string) ([]byte, error) {
 		return challenge.value, nil
 	}
 
-	// reset before populating the internal state
-	t.h.Reset()
-	defer t.h.Reset()
-
-	// write the challenge name, the purpose is to have a domain separator
-	bName := []byte(challengeID)
-	if _, err := t.h.Write(bName); err != nil {
-		return nil, err
+	if !t.isNext(&challenge) {
+		return nil, errPreviousChallengeNotComputed
 	}
+	defer t.h.Reset()
 
-	// write the previous challenge if it's not the first challenge
-	if challenge.position != 0 {
-		if t.previous == nil || (t.previous.position != challenge.position-1) {
-			return nil, errPreviousChallengeNotComputed
-		}
-		if _, err := t.h.Write(t.previous.value[:]); err != nil {
+	// in streaming mode, h may already hold the prefix and the binded values
+	if !challenge.isStreamed {
+		if err := t.writePrefix(challengeID, &challenge); err != nil {
 			return nil, err
 		}
-	}
 
-	// write the binded values in the order they were added
-	if _, err := t.h.Write(challenge.bindings); err != nil {
-		return nil, err
+		// write the binded values in the order they were added
+		if _, err := t.h.Write(challenge.bindings); err != nil {
+			return nil, err
+		}
 	}
 
 	// compute the hash of the accumulated values
@@ -134,3 +212,53 @@ func (t *Transcript) ComputeChallenge(challengeID string) ([]byte, error) {
 	return res, nil
 
 }
+
+// isNext returns true if c is the next challenge to compute, that is if c is the first
+// challenge or if the challenge preceding it is computed.
+func (t *Transcript) isNext(c *challenge) bool {
+	if c.position == 0 {
+		return true
+	}
+	return t.previous != nil && t.previous.position == c.position-1
+}
+
+// writePrefix resets h and writes name || previous_challenge (if c is not the first one).
+func (t *Transcript) writePrefix(challengeID string, c *challenge) error {
+
+	// reset before populating the internal state
+	t.h.Reset()
+
+	// write the challenge name, the purpose is to have a domain separator
+	bName := []byte(challengeID)
+	if t.fieldNative {
+		bName = nameToFieldElements(bName, t.h.BlockSize())
+	}
+	if _, err := t.h.Write(bName); err != nil {
+		return err
+	}
+
+	// write the previous challenge if it's not the first challenge
+	if c.position != 0 {
+		if _, err := t.h.Write(t.previous.value[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nameToFieldElements splits name in chunks of blockSize-1 bytes, each one left padded
+// with a zero byte so that it encodes a field element on blockSize bytes.
+func nameToFieldElements(name []byte, blockSize int) []byte {
+	chunkSize := blockSize - 1
+	nbChunks := (len(name) + chunkSize - 1) / chunkSize
+	res := make([]byte, nbChunks*blockSize)
+	for i := 0; i < nbChunks; i++ {
+		end := (i + 1) * chunkSize
+		if end > len(name) {
+			end = len(name)
+		}
+		chunk := name[i*chunkSize : end]
+		copy(res[(i+1)*blockSize-len(chunk):(i+1)*blockSize], chunk)
+	}
+	return res
+}
@@ -17,7 +17,11 @@ package fiatshamir
 import (
 	"bytes"
 	"crypto/sha256"
+	"hash"
 	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
 )
 
 func initTranscript() Transcript {
@@ -139,3 +143,251 @@ func TestBindToComputedChallenge(t *testing.T) {
 	}
 
 }
+
+func initFieldTranscript(values ...fr.Element) Transcript {
+	fs := NewFieldTranscript(mimc.NewMiMC(), "alpha", "beta")
+	for i := range values {
+		b := values[i].Bytes()
+		if err := fs.Bind("alpha", b[:]); err != nil {
+			panic(err)
+		}
+	}
+	return fs
+}
+
+func TestFieldTranscript(t *testing.T) {
+	t.Parallel()
+
+	var values [3]fr.Element
+	for i := range values {
+		values[i].SetRandom()
+	}
+
+	fs1 := initFieldTranscript(values[:]...)
+	fs2 := initFieldTranscript(values[:]...)
+
+	for _, id := range []string{"alpha", "beta"} {
+		c1, err := fs1.ComputeChallenge(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c2, err := fs2.ComputeChallenge(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// deterministic
+		if !bytes.Equal(c1, c2) {
+			t.Fatal("challenges should be deterministic")
+		}
+
+		// the challenge is a field element, no reduction needed
+		var c fr.Element
+		if err := c.SetBytesCanonical(c1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the challenges depend on the binded values
+	values[0].Double(&values[0])
+	fs3 := initFieldTranscript(values[:]...)
+	c1, _ := fs1.ComputeChallenge("alpha")
+	c3, err := fs3.ComputeChallenge("alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(c1, c3) {
+		t.Fatal("challenges should depend on the binded values")
+	}
+
+	// the challenges differ from the byte-oriented backend
+	fs4 := NewTranscript(mimc.NewMiMC(), "alpha", "beta")
+	for i := range values {
+		b := values[i].Bytes()
+		if err := fs4.Bind("alpha", b[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c4, err := fs4.ComputeChallenge("alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(c3, c4) {
+		t.Fatal("field and byte transcripts should absorb the challenge name differently")
+	}
+
+	// only field elements can be binded
+	if err := fs3.Bind("beta", []byte("not a field element")); err == nil {
+		t.Fatal("binding a value which is not a sequence of field elements should fail")
+	}
+}
+
+func TestAbsorb(t *testing.T) {
+	t.Parallel()
+
+	// two completed sub-protocol transcripts
+	subs := make([]Transcript, 2)
+	for i := range subs {
+		subs[i] = NewTranscript(sha256.New(), "x", "y")
+		if err := subs[i].Bind("x", []byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := subs[i].ComputeChallenge("x"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := subs[i].ComputeChallenge("y"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	merge := func(order ...int) []byte {
+		fs := NewTranscript(sha256.New(), "alpha")
+		for _, i := range order {
+			if err := fs.Absorb("alpha", &subs[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		c, err := fs.ComputeChallenge("alpha")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	// absorbing is binding the challenges of the sub-transcripts, in order
+	fs := NewTranscript(sha256.New(), "alpha")
+	for i := range subs {
+		for _, id := range []string{"x", "y"} {
+			c, _ := subs[i].ComputeChallenge(id)
+			if err := fs.Bind("alpha", c); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	expected, err := fs.ComputeChallenge("alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(merge(0, 1), expected) {
+		t.Fatal("Absorb should bind the challenges of the absorbed transcript in order")
+	}
+
+	// deterministic but order-sensitive
+	if !bytes.Equal(merge(0, 1), merge(0, 1)) {
+		t.Fatal("merging should be deterministic")
+	}
+	if bytes.Equal(merge(0, 1), merge(1, 0)) {
+		t.Fatal("merging in different orders should produce different challenges")
+	}
+
+	// the absorbed transcript must be complete
+	incomplete := NewTranscript(sha256.New(), "x", "y")
+	if _, err := incomplete.ComputeChallenge("x"); err != nil {
+		t.Fatal(err)
+	}
+	fs = NewTranscript(sha256.New(), "alpha")
+	if err := fs.Absorb("alpha", &incomplete); err == nil {
+		t.Fatal("absorbing a transcript with missing challenges should fail")
+	}
+}
+
+func TestStreamingTranscript(t *testing.T) {
+	t.Parallel()
+
+	type binding struct {
+		challengeID string
+		value       []byte
+	}
+	ids := []string{"alpha", "beta", "gamma"}
+
+	// run binds the values, interleaved with the computation of the challenges: the
+	// values binded to a challenge before the previous one is computed are buffered.
+	run := func(fs Transcript, before, after [][]binding) [][]byte {
+		res := make([][]byte, len(ids))
+		for i, id := range ids {
+			for _, b := range before[i] {
+				if err := fs.Bind(b.challengeID, b.value); err != nil {
+					t.Fatal(err)
+				}
+			}
+			c, err := fs.ComputeChallenge(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res[i] = c
+			for _, b := range after[i] {
+				if err := fs.Bind(b.challengeID, b.value); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		return res
+	}
+
+	large := make([]byte, 1<<16)
+	for i := range large {
+		large[i] = byte(i)
+	}
+	before := [][]binding{
+		{{"alpha", []byte("v1")}, {"gamma", []byte("v2")}, {"alpha", large}},
+		{{"beta", []byte("v3")}},
+		{{"gamma", large}},
+	}
+	after := [][]binding{
+		{{"beta", []byte("v4")}, {"gamma", []byte("v5")}},
+		{},
+		{},
+	}
+
+	expected := run(NewTranscript(sha256.New(), ids...), before, after)
+	got := run(NewStreamingTranscript(sha256.New(), ids...), before, after)
+	for i := range ids {
+		if !bytes.Equal(expected[i], got[i]) {
+			t.Fatalf("challenge %s differs from the buffered transcript", ids[i])
+		}
+	}
+
+	// a challenge computed out of order does not disrupt the streamed one
+	fs := NewStreamingTranscript(sha256.New(), ids...)
+	if err := fs.Bind("alpha", []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.ComputeChallenge("beta"); err == nil {
+		t.Fatal("beta should not be computable before alpha")
+	}
+	alpha, err := fs.ComputeChallenge("alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs = NewTranscript(sha256.New(), ids...)
+	if err := fs.Bind("alpha", []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	expectedAlpha, _ := fs.ComputeChallenge("alpha")
+	if !bytes.Equal(alpha, expectedAlpha) {
+		t.Fatal("streamed alpha differs from the buffered transcript")
+	}
+}
+
+func BenchmarkTranscriptBind(b *testing.B) {
+	// binds 1MiB to a challenge, in chunks of 1KiB
+	chunk := make([]byte, 1<<10)
+	bench := func(newTranscript func(hash.Hash, ...string) Transcript) func(b *testing.B) {
+		return func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				fs := newTranscript(sha256.New(), "alpha")
+				for j := 0; j < 1<<10; j++ {
+					if err := fs.Bind("alpha", chunk); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if _, err := fs.ComputeChallenge("alpha"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+	b.Run("buffered", bench(NewTranscript))
+	b.Run("streaming", bench(NewStreamingTranscript))
+}
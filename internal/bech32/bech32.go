@@ -0,0 +1,189 @@
+// Package bech32 implements the bech32 encoding (BIP-173) of byte strings, with a human-readable prefix
+// and a 6-character checksum.
+//
+// Unlike BIP-173, the total length of the encoded string is not limited to 90 characters, as
+// compressed points of some curves don't fit in this limit; the checksum is still guaranteed to
+// detect any error affecting at most 4 characters on strings of up to 89 characters.
+package bech32
+
+import (
+	"errors"
+	"strings"
+)
+
+const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var (
+	ErrInvalidPrefix   = errors.New("bech32: invalid human-readable prefix")
+	ErrInvalidChar     = errors.New("bech32: invalid character")
+	ErrMixedCase       = errors.New("bech32: mixed case string")
+	ErrMissingSep      = errors.New("bech32: missing separator")
+	ErrInvalidChecksum = errors.New("bech32: invalid checksum")
+	ErrInvalidPadding  = errors.New("bech32: invalid padding")
+)
+
+// charsetRev maps a character to its 5-bit value, or -1 if it is not in charset
+var charsetRev [128]int8
+
+func init() {
+	for i := range charsetRev {
+		charsetRev[i] = -1
+	}
+	for i := 0; i < len(charset); i++ {
+		charsetRev[charset[i]] = int8(i)
+	}
+}
+
+// Encode returns the bech32 encoding of data with the human-readable prefix hrp
+//
+// hrp must be non empty, lower case, and made of ASCII characters in the range [33, 126].
+func Encode(hrp string, data []byte) (string, error) {
+	if err := checkPrefix(hrp); err != nil {
+		return "", err
+	}
+	if strings.ToLower(hrp) != hrp {
+		return "", ErrInvalidPrefix
+	}
+
+	values := convertBits(data, 8, 5, true)
+	checksum := createChecksum(hrp, values)
+
+	var sb strings.Builder
+	sb.Grow(len(hrp) + 1 + len(values) + len(checksum))
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, v := range values {
+		sb.WriteByte(charset[v])
+	}
+	for _, v := range checksum {
+		sb.WriteByte(charset[v])
+	}
+	return sb.String(), nil
+}
+
+// Decode returns the human-readable prefix (in lower case) and the data encoded in s
+//
+// It returns an error if s is malformed or if its checksum is invalid.
+func Decode(s string) (hrp string, data []byte, err error) {
+	lower := strings.ToLower(s)
+	if lower != s && strings.ToUpper(s) != s {
+		return "", nil, ErrMixedCase
+	}
+	s = lower
+
+	// the separator is the last '1', hrp may contain '1'
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 0 {
+		return "", nil, ErrMissingSep
+	}
+	hrp = s[:sep]
+	if err = checkPrefix(hrp); err != nil {
+		return "", nil, err
+	}
+	if len(s)-sep-1 < 6 {
+		return "", nil, ErrInvalidChecksum
+	}
+
+	values := make([]byte, len(s)-sep-1)
+	for i := range values {
+		c := s[sep+1+i]
+		if c >= 128 || charsetRev[c] < 0 {
+			return "", nil, ErrInvalidChar
+		}
+		values[i] = byte(charsetRev[c])
+	}
+	if polymod(append(expandPrefix(hrp), values...)) != 1 {
+		return "", nil, ErrInvalidChecksum
+	}
+
+	values = values[:len(values)-6]
+	if data, err = convertBitsStrict(values); err != nil {
+		return "", nil, err
+	}
+	return hrp, data, nil
+}
+
+// checkPrefix checks that hrp is non empty and made of ASCII characters in the range [33, 126]
+func checkPrefix(hrp string) error {
+	if len(hrp) == 0 {
+		return ErrInvalidPrefix
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return ErrInvalidPrefix
+		}
+	}
+	return nil
+}
+
+// polymod computes the BCH checksum of values, as specified in BIP-173
+func polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// expandPrefix returns the high bits of the characters of hrp, a zero, then their low bits
+func expandPrefix(hrp string) []byte {
+	res := make([]byte, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		res[i] = hrp[i] >> 5
+		res[len(hrp)+1+i] = hrp[i] & 31
+	}
+	return res
+}
+
+// createChecksum returns the 6 5-bit values of the checksum of values with prefix hrp
+func createChecksum(hrp string, values []byte) []byte {
+	buf := append(expandPrefix(hrp), values...)
+	buf = append(buf, 0, 0, 0, 0, 0, 0)
+	mod := polymod(buf) ^ 1
+	res := make([]byte, 6)
+	for i := range res {
+		res[i] = byte(mod>>(5*(5-i))) & 31
+	}
+	return res
+}
+
+// convertBits regroups the fromBits-bit values of data into toBits-bit values, padding the last
+// one with zeroes if pad is set
+func convertBits(data []byte, fromBits, toBits uint, pad bool) []byte {
+	var acc uint32
+	var nbBits uint
+	maxv := uint32(1)<<toBits - 1
+	res := make([]byte, 0, (len(data)*int(fromBits)+int(toBits)-1)/int(toBits))
+	for _, v := range data {
+		acc = acc<<fromBits | uint32(v)
+		nbBits += fromBits
+		for nbBits >= toBits {
+			nbBits -= toBits
+			res = append(res, byte((acc>>nbBits)&maxv))
+		}
+	}
+	if pad && nbBits > 0 {
+		res = append(res, byte((acc<<(toBits-nbBits))&maxv))
+	}
+	return res
+}
+
+// convertBitsStrict regroups the 5-bit values into bytes, and rejects a padding of
+// more than 4 bits or with non-zero bits
+func convertBitsStrict(values []byte) ([]byte, error) {
+	nbBits := len(values) * 5 % 8
+	if nbBits > 4 {
+		return nil, ErrInvalidPadding
+	}
+	if nbBits > 0 && values[len(values)-1]&(1<<nbBits-1) != 0 {
+		return nil, ErrInvalidPadding
+	}
+	return convertBits(values, 5, 8, false), nil
+}
@@ -0,0 +1,91 @@
+package bech32
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestValidChecksum(t *testing.T) {
+	// test vectors from BIP-173
+	valid := []string{
+		"A12UEL5L",
+		"a12uel5l",
+		"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"11qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqc8247j",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+		"?1ezyfcl",
+	}
+	for _, s := range valid {
+		hrp, _, err := Decode(s)
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		if hrp != strings.ToLower(s[:strings.LastIndexByte(s, '1')]) {
+			t.Fatalf("%s: unexpected prefix %s", s, hrp)
+		}
+	}
+}
+
+func TestInvalidChecksum(t *testing.T) {
+	// test vectors from BIP-173 (the 90 characters limit is not enforced)
+	invalid := []string{
+		"\x201nwldj5",   // hrp character out of range
+		"\x7f1axkwrx",   // hrp character out of range
+		"pzry9x0s0muk",  // no separator
+		"1pzry9x0s0muk", // empty hrp
+		"x1b4n0q5v",     // invalid data character
+		"li1dgmt3",      // too short checksum
+		"de1lg7wt\xff",  // invalid character in checksum
+		"A1G7SGD8",      // checksum calculated with uppercase form of hrp
+		"10a06t8",       // empty hrp
+		"1qzzfhee",      // empty hrp
+		"a12UEL5L",      // mixed case
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxx", // wrong checksum
+	}
+	for _, s := range invalid {
+		if _, _, err := Decode(s); err == nil {
+			t.Fatalf("%q: expected an error", s)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 3, 4, 5, 32, 48, 96, 192} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i*7 + size)
+		}
+		s, err := Encode("pk", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hrp, decoded, err := Decode(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hrp != "pk" || !bytes.Equal(decoded, data) {
+			t.Fatalf("size %d: round trip failed", size)
+		}
+		if hrp, decoded, err = Decode(strings.ToUpper(s)); err != nil || hrp != "pk" || !bytes.Equal(decoded, data) {
+			t.Fatalf("size %d: round trip failed (upper case)", size)
+		}
+
+		// altering any character must be detected
+		for i := len("pk1"); i < len(s); i++ {
+			altered := []byte(s)
+			altered[i] = charset[(strings.IndexByte(charset, s[i])+1)%len(charset)]
+			if _, _, err := Decode(string(altered)); err == nil {
+				t.Fatalf("size %d: altered character %d not detected", size, i)
+			}
+		}
+	}
+
+	if _, err := Encode("", nil); err != ErrInvalidPrefix {
+		t.Fatal("expected ErrInvalidPrefix")
+	}
+	if _, err := Encode("PK", nil); err != ErrInvalidPrefix {
+		t.Fatal("expected ErrInvalidPrefix")
+	}
+}
@@ -66,6 +66,9 @@ type FieldConfig struct {
 	SqrtQ3Mod4Exponent        string   // big.Int to base16 string
 	SqrtG                     []uint64 // NonResidue ^  SqrtR (montgomery form)
 	NonResidue                big.Int  // (montgomery form)
+	TwoAdicity                uint64   // largest e such that 2ᵉ divides q-1
+	RootOfUnity               []uint64 // primitive 2^TwoAdicity-th root of unity (montgomery form)
+	MultiplicativeGenerator   uint64   // generator of the multiplicative group, 0 if unknown
 	LegendreExponentData      *addchain.AddChainData
 	SqrtAtkinExponentData     *addchain.AddChainData
 	SqrtSMinusOneOver2Data    *addchain.AddChainData
@@ -254,6 +257,19 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 		}
 	}
 
+	// 2-adic subgroup; the root of unity is derived from the smallest quadratic non-residue
+	// unless a multiplicative generator is provided through SetMultiplicativeGenerator
+	{
+		var s, nonResidue big.Int
+		s.Sub(&bModulus, big.NewInt(1))
+		F.TwoAdicity = uint64(s.TrailingZeroBits())
+		nonResidue.SetInt64(2)
+		for big.Jacobi(&nonResidue, &bModulus) != -1 {
+			nonResidue.Add(&nonResidue, big.NewInt(1))
+		}
+		F.setRootOfUnity(&nonResidue)
+	}
+
 	// note: to simplify output files generated, we generated ASM code only for
 	// moduli that meet the condition F.NoCarry
 	// asm code generation for moduli with more than 6 words can be optimized further
@@ -262,6 +278,31 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 	return F, nil
 }
 
+// SetMultiplicativeGenerator records g as the generator of the multiplicative group
+// and derives RootOfUnity from it, so that RootOfUnity = g^((q-1)/2^TwoAdicity).
+//
+// The generator can't be found without the factorization of q-1, hence it is not
+// computed by NewFieldConfig; only a necessary condition (g is not a square) is checked.
+func (F *FieldConfig) SetMultiplicativeGenerator(g uint64) error {
+	var bg big.Int
+	bg.SetUint64(g)
+	if big.Jacobi(&bg, F.ModulusBig) != -1 {
+		return fmt.Errorf("%d is a square mod q, it can't generate the multiplicative group", g)
+	}
+	F.MultiplicativeGenerator = g
+	F.setRootOfUnity(&bg)
+	return nil
+}
+
+// setRootOfUnity sets RootOfUnity to nonResidue^((q-1)/2^TwoAdicity), in montgomery form
+func (F *FieldConfig) setRootOfUnity(nonResidue *big.Int) {
+	var s, root big.Int
+	s.Sub(F.ModulusBig, big.NewInt(1)).Rsh(&s, uint(F.TwoAdicity))
+	root.Exp(nonResidue, &s, F.ModulusBig)
+	root.Lsh(&root, uint(F.NbWords)*64).Mod(&root, F.ModulusBig)
+	F.RootOfUnity = toUint64Slice(&root, F.NbWords)
+}
+
 func toUint64Slice(b *big.Int, nbWords ...int) (s []uint64) {
 	if len(nbWords) > 0 && nbWords[0] > len(b.Bits()) {
 		s = make([]uint64, nbWords[0])
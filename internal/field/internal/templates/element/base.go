@@ -7,17 +7,22 @@ import (
 	"math/bits"
 	"io"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
 	"sync"
 	"strconv"
 	"errors"
 	"reflect"
 	"strings"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
 // {{.ElementName}} represents a field element stored on {{.NbWords}} words (uint64)
 // 
 // {{.ElementName}} are assumed to be in Montgomery form in all methods.
+// Conversions from and to big.Int follow this convention: SetBigInt and ToBigIntRegular
+// deal with regular integers, while ToBigInt returns the Montgomery form.
 // 
 // Modulus q =
 //
@@ -94,6 +99,8 @@ func (z *{{.ElementName}}) SetUint64(v uint64) *{{.ElementName}} {
 }
 
 // SetInt64 sets z to v and returns z
+//
+// Negative values are mapped to q - |v|; for instance SetInt64(-1) sets z to q - 1.
 func (z *{{.ElementName}}) SetInt64(v int64) *{{.ElementName}} {
 
 	// absolute value of v
@@ -194,6 +201,9 @@ func (z *{{.ElementName}}) SetOne() *{{.ElementName}} {
 
 
 // Div z = x*y⁻¹ (mod q)
+//
+// It performs a single inversion. There is no zero-divisor check: if y == 0, sets and returns z = 0
+// (see Inverse), callers that must reject a zero divisor check y.IsZero() first.
 func (z *{{.ElementName}}) Div( x, y *{{.ElementName}}) *{{.ElementName}} {
 	var yInv {{.ElementName}}
 	yInv.Inverse( y)
@@ -290,19 +300,19 @@ func (z *{{.ElementName}}) Cmp(x *{{.ElementName}}) int {
 // larger than its negation, false otherwise
 func (z *{{.ElementName}}) LexicographicallyLargest() bool {
 	// adapted from github.com/zkcrypto/bls12_381
-	// we check if the element is larger than (q-1) / 2
-	// if z - (((q -1) / 2) + 1) have no underflow, then z > (q-1) / 2
+	// we check if the element is larger than (q-1) / 2, that is, if z >= ((q-1) / 2) + 1;
+	// limbs are compared from the most significant one, the first that differs decides.
 
 	_z := *z
 	_z.FromMont()
 
-	var b uint64
-	_, b = bits.Sub64(_z[0], {{index .QMinusOneHalvedP 0}}, 0)
-	{{- range $i := .NbWordsIndexesNoZero}}
-		_, b = bits.Sub64(_z[{{$i}}], {{index $.QMinusOneHalvedP $i}}, b)
+	{{- range $i := reverse .NbWordsIndexesFull}}
+	if _z[{{$i}}] != {{index $.QMinusOneHalvedP $i}} {
+		return _z[{{$i}}] > {{index $.QMinusOneHalvedP $i}}
+	}
 	{{- end}}
 
-	return b == 0
+	return true
 }
 
 // SetRandom sets z to a uniform random value in [0, q).
@@ -364,6 +374,30 @@ func (z *{{.ElementName}}) smallerThanModulus() bool {
 	{{-  end }}
 }
 
+// IsReduced returns true if the limbs of z represent a value strictly smaller than q.
+//
+// Elements produced by the arithmetic of this package are always reduced; a non-reduced
+// element can only be built by setting the limbs directly, and is meant to be detected
+// when debugging or validating untrusted data. This is not constant time.
+func (z *{{.ElementName}}) IsReduced() bool {
+	return z.smallerThanModulus()
+}
+
+// Canonical returns a copy of z, in Montgomery form, with fully reduced limbs (i.e. smaller than q).
+//
+// Elements equal as per Equal have identical Canonical limbs; it is meant to be used
+// when the raw limbs are hashed or compared directly.
+func (z *{{.ElementName}}) Canonical() {{.ElementName}} {
+	res := *z
+	if !res.smallerThanModulus() {
+		// the limbs may encode a value ⩾ 2q, so subtracting q once is not enough;
+		// SetBigInt reduces modulo q, and FromMont undoes its conversion to Montgomery form
+		var v big.Int
+		res.SetBigInt(z.ToBigInt(&v)).FromMont()
+	}
+	return res
+}
+
 // One returns 1
 func One() {{.ElementName}} {
 	var one {{.ElementName}}
@@ -474,6 +508,29 @@ func (z *{{.ElementName}}) Square(x *{{.ElementName}}) *{{.ElementName}} {
 	return z
 }
 
+// SquareAssign z = z * z (mod q)
+{{- if $.NoCarry}}
+//
+// z must be strictly inferior to q
+{{- end }}
+func (z *{{.ElementName}}) SquareAssign() *{{.ElementName}} {
+	return z.Square(z)
+}
+
+// SquareN z = x^(2ⁿ) (mod q), that is x squared n times.
+// It returns x if n <= 0.
+{{- if $.NoCarry}}
+//
+// x must be strictly inferior to q
+{{- end }}
+func (z *{{.ElementName}}) SquareN(x *{{.ElementName}}, n int) *{{.ElementName}} {
+	z.Set(x)
+	for i := 0; i < n; i++ {
+		z.Square(z)
+	}
+	return z
+}
+
 // FromMont converts z in place (i.e. mutates) from Montgomery to regular representation
 // sets and returns z = z * 1
 func (z *{{.ElementName}}) FromMont() *{{.ElementName}} {
@@ -557,6 +614,34 @@ func (z *{{.ElementName}}) Double( x *{{.ElementName}}) *{{.ElementName}} {
 	return z
 }
 
+// MulByConstant x *= c (mod q) for a small constant c
+//
+{{- if eq 1 $.NbWords}}
+// On a single-word field, a Mul by c is cheaper than a double-and-add chain, and is used.
+{{- else}}
+// It runs a double-and-add chain over the bits of c, which is cheaper than a Mul;
+// MulBy3, MulBy5 and MulBy13 cover the common constants.
+{{- end}}
+func MulByConstant(x *{{.ElementName}}, c uint8) {
+	{{- if eq 1 $.NbWords}}
+	var y {{.ElementName}}
+	y.SetUint64(uint64(c))
+	x.Mul(x, &y)
+	{{- else}}
+	if c == 0 {
+		x.SetZero()
+		return
+	}
+	_x := *x
+	for i := bits.Len8(c) - 2; i >= 0; i-- {
+		x.Double(x)
+		if (c>>uint(i))&1 == 1 {
+			x.Add(x, &_x)
+		}
+	}
+	{{- end}}
+}
+
 
 // Sub z = x - y (mod q)
 func (z *{{.ElementName}}) Sub( x, y *{{.ElementName}}) *{{.ElementName}} {
@@ -686,6 +771,21 @@ func BatchInvert(a []{{.ElementName}}) []{{.ElementName}} {
 	return res
 }
 
+// VecScale sets dst[i] = a[i] ⋅ sⁱ for i in [0, len(a)), computing the powers of s on the fly,
+// e.g. to move the coefficients of a polynomial P to those of P(s⋅X) before a coset FFT.
+//
+// dst and a may be the same slice. It panics if len(dst) < len(a).
+func VecScale(dst, a []{{.ElementName}}, s {{.ElementName}}) {
+	if len(dst) < len(a) {
+		panic("VecScale: len(dst) < len(a)")
+	}
+	acc := One()
+	for i := 0; i < len(a); i++ {
+		dst[i].Mul(&a[i], &acc)
+		acc.Mul(&acc, &s)
+	}
+}
+
 func _butterflyGeneric(a, b *{{.ElementName}}) {
 	t := *a
 	a.Add(a, b)
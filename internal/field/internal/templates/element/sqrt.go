@@ -22,15 +22,22 @@ func init() {
 
 {{- end }}
 
+// EulerCriterion sets z = x^((q-1)/2) (mod q), the exponentiation of Euler's criterion
+// used by Legendre, and returns z. z is 1 if x is a non-zero square, -1 if x is not a
+// square and 0 if x is 0.
+func (z *{{.ElementName}}) EulerCriterion(x *{{.ElementName}}) *{{.ElementName}} {
+	{{- if .UseAddChain}}
+	return z.expByLegendreExp(*x)
+	{{- else}}
+	return z.Exp(*x, _bLegendreExponent{{.ElementName}})
+	{{- end}}
+}
+
 // Legendre returns the Legendre symbol of z (either +1, -1, or 0.)
 func (z *{{.ElementName}}) Legendre() int {
 	var l {{.ElementName}}
 	// z^((q-1)/2)
-	{{- if .UseAddChain}}
-	l.expByLegendreExp(*z)
-	{{- else}}
-	l.Exp(*z, _bLegendreExponent{{.ElementName}})
-	{{- end}}
+	l.EulerCriterion(z)
 	
 	if l.IsZero() {
 		return 0
@@ -44,6 +51,27 @@ func (z *{{.ElementName}}) Legendre() int {
 }
 
 
+// BatchSqrt returns the square roots of the elements of in (as Sqrt), and for each element
+// whether it is a square; the roots of the non-squares are set to 0.
+//
+// The square roots are computed in parallel.
+func BatchSqrt(in []{{.ElementName}}) ([]{{.ElementName}}, []bool) {
+	res := make([]{{.ElementName}}, len(in))
+	isSquare := make([]bool, len(in))
+	parallel.Execute(len(in), func(start, end int) {
+		for i := start; i < end; i++ {
+			isSquare[i] = res[i].Sqrt(&in[i]) != nil
+		}
+	})
+	return res, isSquare
+}
+
+// IsSquare returns true if z is a square (quadratic residue) mod q, including 0
+func (z *{{.ElementName}}) IsSquare() bool {
+	return z.Legendre() != -1
+}
+
+
 // Sqrt z = √x (mod q)
 // if the square root doesn't exist (x is not a square mod q)
 // Sqrt leaves z unchanged and returns nil
@@ -157,6 +185,40 @@ func (z *{{.ElementName}}) Sqrt(x *{{.ElementName}}) *{{.ElementName}} {
 	{{- end}}
 }
 
+// TwoAdicity returns the largest e such that 2ᵉ divides q-1
+func TwoAdicity() uint64 {
+	return {{.TwoAdicity}}
+}
+
+// Generator returns a primitive 2^logSize-th root of unity
+//
+// The roots are consistent across sizes: Generator(logSize-1) = Generator(logSize)².
+// It panics if logSize is negative or larger than TwoAdicity().
+func Generator(logSize int) {{.ElementName}} {
+	if logSize < 0 || uint64(logSize) > TwoAdicity() {
+		panic("logSize is out of range: the required root of unity does not exist")
+	}
+	// primitive 2^TwoAdicity-th root of unity
+	res := {{.ElementName}}{
+		{{- range $i := .RootOfUnity}}
+		{{$i}},{{end}}
+	}
+	for i := uint64(logSize); i < TwoAdicity(); i++ {
+		res.Square(&res)
+	}
+	return res
+}
+
+{{- if .MultiplicativeGenerator}}
+
+// MultiplicativeGenerator returns a generator of the multiplicative group of the field
+//
+// Generator(TwoAdicity()) is MultiplicativeGenerator()^((q-1)/2^TwoAdicity()).
+func MultiplicativeGenerator() {{.ElementName}} {
+	return New{{.ElementName}}({{.MultiplicativeGenerator}})
+}
+{{- end}}
+
 
 
 `
@@ -1,11 +1,12 @@
 package config
 
 var BLS24_317 = Curve{
-	Name:         "bls24-317",
-	CurvePackage: "bls24317",
-	EnumID:       "BLS24_317",
-	FrModulus:    "30869589236456844204538189757527902584594726589286811523515204428962673459201",
-	FpModulus:    "136393071104295911515099765908274057061945112121419593977210139303905973197232025618026156731051",
+	Name:                "bls24-317",
+	CurvePackage:        "bls24317",
+	EnumID:              "BLS24_317",
+	FrModulus:           "30869589236456844204538189757527902584594726589286811523515204428962673459201",
+	FrMultiplicativeGen: 7,
+	FpModulus:           "136393071104295911515099765908274057061945112121419593977210139303905973197232025618026156731051",
 	G1: Point{
 		CoordType:        "fp.Element",
 		CoordExtDegree:   1,
@@ -15,6 +15,8 @@ type Curve struct {
 	FpModulus    string
 	FrModulus    string
 
+	FrMultiplicativeGen uint64 // generator of Fr*
+
 	Fp           *field.FieldConfig
 	Fr           *field.FieldConfig
 	FpUnusedBits int
@@ -57,6 +59,7 @@ type Point struct {
 	PointName        string
 	GLV              bool     // scalar multiplication using GLV
 	CofactorCleaning bool     // flag telling if the Cofactor cleaning is available
+	CofactorOne      bool     // the group of points is of prime order r (cofactor = 1), e.g. E(𝔽p) for BN curves
 	CRange           []int    // multiexp bucket method: generate inner methods (with const arrays) for each c
 	Projective       bool     // generate projective coordinates
 	A                []string //A linear coefficient in Weierstrass form
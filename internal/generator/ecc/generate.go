@@ -18,6 +18,7 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "multiexp_test.go"), Templates: []string{"tests/multiexp.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal_test.go"), Templates: []string{"tests/marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "vectors_test.go"), Templates: []string{"tests/vectors.go.tmpl"}},
 	}
 	conf.Package = packageName
 	if err := bgen.Generate(conf, packageName, "./ecc/template", entries...); err != nil {
@@ -18,10 +18,12 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/fft"
 	fri "github.com/consensys/gnark-crypto/internal/generator/fri/template"
 	"github.com/consensys/gnark-crypto/internal/generator/kzg"
+	"github.com/consensys/gnark-crypto/internal/generator/merkletree"
 	"github.com/consensys/gnark-crypto/internal/generator/pairing"
 	"github.com/consensys/gnark-crypto/internal/generator/permutation"
 	"github.com/consensys/gnark-crypto/internal/generator/plookup"
 	"github.com/consensys/gnark-crypto/internal/generator/polynomial"
+	"github.com/consensys/gnark-crypto/internal/generator/schnorr"
 	"github.com/consensys/gnark-crypto/internal/generator/tower"
 )
 
@@ -50,6 +52,7 @@ func main() {
 
 			conf.Fr, err = field.NewFieldConfig("fr", "Element", conf.FrModulus, true)
 			assertNoError(err)
+			assertNoError(conf.Fr.SetMultiplicativeGenerator(conf.FrMultiplicativeGen))
 
 			conf.FpUnusedBits = 64 - (conf.Fp.NbBits % 64)
 
@@ -77,9 +80,15 @@ func main() {
 			// generate mimc on fr
 			assertNoError(mimc.Generate(conf, filepath.Join(curveDir, "fr", "mimc"), bgen))
 
+			// generate merkle tree on fr
+			assertNoError(merkletree.Generate(conf, filepath.Join(curveDir, "fr", "merkletree"), bgen))
+
 			// generate eddsa on companion curves
 			assertNoError(fri.Generate(conf, filepath.Join(curveDir, "fr", "fri"), bgen))
 
+			// generate schnorr signatures over G1
+			assertNoError(schnorr.Generate(conf, filepath.Join(curveDir, "schnorr"), bgen))
+
 			// generate G1, G2, multiExp, ...
 			assertNoError(ecc.Generate(conf, curveDir, bgen))
 
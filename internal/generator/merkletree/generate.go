@@ -0,0 +1,21 @@
+package merkletree
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// merkle tree over fr
+	conf.Package = "merkletree"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "merkletree.go"), Templates: []string{"merkletree.go.tmpl"}},
+		{File: filepath.Join(baseDir, "merkletree_test.go"), Templates: []string{"merkletree.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./merkletree/template/", entries...)
+
+}
@@ -0,0 +1,71 @@
+package schnorr
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+// knownAnswer holds the private key generated from the seed 00 01 … 1f and its signature of
+// "gnark-crypto", hex encoded, checked by the generated tests
+type knownAnswer struct {
+	KnownPrivateKey string
+	KnownSignature  string
+}
+
+var knownAnswers = map[string]knownAnswer{
+	"bls12-377": {
+		KnownPrivateKey: "8094977ab9e47a174ce348ccea2dbafeb56de3c7ef8059c897882ca9e28c58df1e51b0b585a3edce2a3b00206a073bae037d9493f0833efe1efda10403d5945342d46c7924c4e7ac8c183d9962c981c7",
+		KnownSignature:  "a08fcf8e74732ef36187b3120785e9b8a25f128bb793016a22957ffb38f166a868b22249afd2f25cdaa5d49ab0ebd2a501f01e2d71a8c369e0557b9b225aac944f2b280bfd24884ad9c265c1b62bc4e5",
+	},
+	"bls12-378": {
+		KnownPrivateKey: "a004f50aa74ed100ff5b1f64aea29c43b6ea9e4cf5437840f9b3380b69b567f592187473bb7ef2329fb8c91cdfc2d2431b8bac734ec4d42dd2ac2c2f741e81fc51673d26ff241707be73e7c611e259d4",
+		KnownSignature:  "a0971f6b1a407f311a6e44665fc00041a962aef28d20cdd78e107d26ea1a700368c432b9e45d6753a90697fbcdd2029e12342fb5d3f2c1e037ca8647ddcdc20608db64c87fad6501fd93b6a461e71142",
+	},
+	"bls12-381": {
+		KnownPrivateKey: "89c03753ac84a2e71b216c612acb6018aa6c252772c19bab6c897a1f59286a36ee7e14df3f15a00c3d6f10aeb6090f6f5934ad8daa09b2b7e594d6a9c14a0a20bff63ea721eb41f589d241b6d5521b34",
+		KnownSignature:  "a4d86b1326e46495f4419a40b896f0d17bc25a54a80bda119a6ba01f20a617575d16df6a106430d7513dd689f417ef5e11aaaaef60696a46344090a90eb449fa20d5086d41eed143c1abaf9bf6b0b98d",
+	},
+	"bls24-315": {
+		KnownPrivateKey: "a3f430b49f037f7c714168208b980a8b595e9ff512a90402f9b555a1d7dc892d4cb233612be650fc09860ef4ca6741d02995d338b717d3e6dae921a39c4fa5528b0208e184f6c72a",
+		KnownSignature:  "a27ce42a5b29cf3612ce177895fa0f08156cac6a55c5cca17634ba1d7d9af41d63d882fd40e807eb0de4b19649fd227462c7b61a1d7131b35a25bf408a492f75e5e754f1d501ffde",
+	},
+	"bls24-317": {
+		KnownPrivateKey: "a44affc3297d71e0c145b6c5e7cfa7b9c58d1b90c917531ba821a48e79bda45fa79ead93c335d26c1232edbbc66f5e845b7bfbc5d0ef82b31136a40ad4d60a80de1117a514520ae4",
+		KnownSignature:  "88d4151c474dfbe83105202b9b49cf5b9a1161e2d4bdcc47f8d170c7b81e8dcf8fbaf4c53d52127f0882e2f22d865d7af28700bf9df8823e084c3be0d825d3a3668d864e00a9e89d",
+	},
+	"bn254": {
+		KnownPrivateKey: "d1eb8697aed702609856fceee119664f54dbcde235d9fe056712039f9288cb7118840c1ab8fead45e7c3cd7e58559d33097b713ba5d24561187afc7a6bafa298",
+		KnownSignature:  "aeda6b737e8353bb262f89cc48c7e6740b2f24aca9feeaff3cfd28a6e9c9df5c2cdde7a4e4656295f6d28b1c97a5436638bd39ccb39bc5ad410da771fab44331",
+	},
+	"bw6-633": {
+		KnownPrivateKey: "a0b270e1bbee3652b3fb40d760192330ad027a87ac2fc0132888afd87da0ac7f0418610d6c12b5b8a22fbe4c6bfbc535429c7ab02a92b3bad52f9e5112bc738937eddd7f076aab6b3e24832b8268aa3002d080a9a7dd54b54261d4b85cb4469eb0fb253dd0ae38a72e17af686f154623d246a4aceeb89ec0",
+		KnownSignature:  "80fe55c5a73d46e5c16a4319cd8c01cbc7b586bfd8dac7f0e0d7d8fb5fa748dcb700b24d99267c23ee382dccb0dbd528fe450b70094756d7f52b6b3bc89181088b0f0cf802bb1b1a82de1d98cbb9383000eeaf02a8c4aeac7753c7a2486a7673e69e2994edfcdcf70ff849ade7635dc4d43c480f92d447e1",
+	},
+	"bw6-756": {
+		KnownPrivateKey: "800bd2758ab6c18dfe4562b164b19fdfe08259c8e358d72ceec5ab0ba7171e7affe0349b54f71b80929ef2b029282533bf57717ff680c6ed6f29950785958cc09f7c8f031fedb8c99a4ca3cb009a4c0db86c049547622d1955b3e23f9d1d749e002fe1c9e87f38aa5076c2ac2bba6808bd9e465651777bd3cc5419786adbecfd09b5f9a493ace9ff9af2d62fe50c7a40",
+		KnownSignature:  "80017a25464ec0c04d3b81ea4d0117e44b40bf14b1e98b3d672899734e5f84ee4f3fa48c01e14cac01695452721b8c67146008654e8ede7c6ba293c99508658da0381d6ac727ac195975fbf165e889733fcecbc5252dbf509c4a587df73716df02e4cf172ce8f622896c84cdeb4981a3a2dde73b26994f06274561e6729ba969f31468aaeb5f0c01b363e441f9fa75b9",
+	},
+	"bw6-761": {
+		KnownPrivateKey: "a1210b71058621bc9f4ce3d2134e03456bd919087408f0bec5ebe64f346cc49cb67067ba7683f2393bfafc8220d91e5b0df55d0bab8593445b38276ffc04358c8eaaa7dee0d1d89d2385ae81f800fde27f17483cb65b95d35f4f84f8ba63783e00f81d1551386bc3889c7fd1e23f41ff230b37f03669a115db66ab622f069ea18979441de6b27ed0694d5ac6e2d21cba",
+		KnownSignature:  "a11b65f545c547e901ac6ff12b07a9e5347bab0f70e20478023f5320b3f2f36de7ca8920223ac01d616a6033991b99a2f66f91f0f7339362e56273e32622feebfd44b9d2bcf4cc2f611251ed15ed08d760fd22418694df6d493c6fc5de7ac401000a9e18755b8007e1bda2139cc2889cfd388712deed1844bf42b478c4e2904f44df017685f871b32d8c0dc4ea70ca15",
+	},
+}
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// schnorr signatures over G1
+	conf.Package = "schnorr"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "schnorr.go"), Templates: []string{"schnorr.go.tmpl"}},
+		{File: filepath.Join(baseDir, "schnorr_test.go"), Templates: []string{"schnorr.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+	}
+	data := struct {
+		config.Curve
+		knownAnswer
+	}{conf, knownAnswers[conf.Name]}
+	return bgen.Generate(data, conf.Package, "./schnorr/template/", entries...)
+
+}
@@ -6,12 +6,20 @@ import (
 )
 
 // Execute process in parallel the work function
+//
+// maxCpus optionally caps the number of go routines spawned (default: runtime.NumCPU(), also used if maxCpus[0] <= 0).
+// If it is 1, work is called once, on the whole range, in the calling go routine.
 func Execute(nbIterations int, work func(int, int), maxCpus ...int) {
 
 	nbTasks := runtime.NumCPU()
-	if len(maxCpus) == 1 {
+	if len(maxCpus) == 1 && maxCpus[0] > 0 {
 		nbTasks = maxCpus[0]
 	}
+	if nbTasks == 1 {
+		// no need to spawn a go routine
+		work(0, nbIterations)
+		return
+	}
 	nbIterationsPerCpus := nbIterations / nbTasks
 
 	// more CPUs than tasks: a CPU will work on exactly one iteration
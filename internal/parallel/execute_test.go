@@ -0,0 +1,43 @@
+package parallel
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestExecuteCoversRange(t *testing.T) {
+	for _, nbIterations := range []int{0, 1, 7, 64, 1000} {
+		for _, nbTasks := range []int{0, 1, 2, 3, 16, 2000} {
+			var lock sync.Mutex
+			seen := make([]int, nbIterations)
+			Execute(nbIterations, func(start, end int) {
+				lock.Lock()
+				defer lock.Unlock()
+				for i := start; i < end; i++ {
+					seen[i]++
+				}
+			}, nbTasks)
+			for i := range seen {
+				if seen[i] != 1 {
+					t.Fatalf("nbIterations=%d nbTasks=%d: iteration %d processed %d times", nbIterations, nbTasks, i, seen[i])
+				}
+			}
+		}
+	}
+}
+
+func TestExecuteSerial(t *testing.T) {
+	const nbIterations = 100
+
+	// with a single task, work must be called once, on the whole range, without spawning a go routine
+	nbCalls := 0
+	Execute(nbIterations, func(start, end int) {
+		nbCalls++
+		if start != 0 || end != nbIterations {
+			t.Fatalf("expected [0, %d), got [%d, %d)", nbIterations, start, end)
+		}
+	}, 1)
+	if nbCalls != 1 {
+		t.Fatalf("expected 1 call, got %d", nbCalls)
+	}
+}